@@ -0,0 +1,16 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_account_limits" "limits" {
+  region_id = data.gcore_region.rg.id
+}
+
+locals {
+  gpu_available = data.gcore_account_limits.limits.regional_quotas["gpu_count_limit"] - data.gcore_account_limits.limits.regional_quotas["gpu_count_usage"]
+  waap_enabled  = lookup(data.gcore_account_limits.limits.services, "WAAP", false)
+}
@@ -19,3 +19,7 @@ data "gcore_ai_cluster" "c" {
 output "view" {
   value = data.gcore_ai_cluster.c
 }
+
+output "ansible_inventory" {
+  value = join("\n", [for node in data.gcore_ai_cluster.c.node_addresses : "${node.instance_name} ansible_host=${node.internal_ipv4[0]}"])
+}
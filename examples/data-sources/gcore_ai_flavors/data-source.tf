@@ -0,0 +1,22 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_ai_flavors" "h100" {
+  gpu_model     = "H100"
+  min_gpu_count = 8
+  region_id     = data.gcore_region.rg.id
+  project_id    = data.gcore_project.pr.id
+}
+
+output "h100_flavor_id" {
+  value = data.gcore_ai_flavors.h100.ids[0]
+}
@@ -0,0 +1,23 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_ai_images" "ubuntu" {
+  name         = "ubuntu-22.04-x64-nvidia"
+  visibility   = "public"
+  is_baremetal = true
+  region_id    = data.gcore_region.rg.id
+  project_id   = data.gcore_project.pr.id
+}
+
+output "newest_gpu_image_id" {
+  value = data.gcore_ai_images.ubuntu.ids[0]
+}
@@ -0,0 +1,12 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_origingroup" "shared" {
+  origin_group_id = 42
+}
+
+resource "gcore_cdn_resource" "cdn_example_com" {
+  cname        = "cdn.example.com"
+  origin_group = data.gcore_cdn_origingroup.shared.id
+}
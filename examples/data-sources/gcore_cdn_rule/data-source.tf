@@ -0,0 +1,16 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_rule" "images" {
+  resource_id = 1
+  rule_id     = 10
+}
+
+output "images_rule_active" {
+  value = data.gcore_cdn_rule.images.active
+}
+
+output "images_edge_cache" {
+  value = data.gcore_cdn_rule.images.options[0].edge_cache_settings
+}
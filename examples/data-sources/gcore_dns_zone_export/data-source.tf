@@ -0,0 +1,19 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_dns_zone_export" "example" {
+  zone = "example.com"
+}
+
+output "zone_file" {
+  value = data.gcore_dns_zone_export.example.bind
+}
+
+// adopt every RRSet of the zone at once, requires terraform 1.7+,
+// gcore_dns_zone_record.all should be declared with for_each over the same keys
+import {
+  for_each = { for r in data.gcore_dns_zone_export.example.rrsets : "${r.domain}/${r.type}" => r }
+  to       = gcore_dns_zone_record.all[each.key]
+  id       = each.value.import_id
+}
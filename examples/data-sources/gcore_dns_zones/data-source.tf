@@ -0,0 +1,18 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_dns_zones" "all" {
+}
+
+data "gcore_dns_zones" "example" {
+  names = ["example"]
+}
+
+output "zones" {
+  value = data.gcore_dns_zones.example.zones
+}
+
+output "total_zones" {
+  value = data.gcore_dns_zones.all.total_amount
+}
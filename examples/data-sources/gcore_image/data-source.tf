@@ -16,6 +16,16 @@ data "gcore_image" "ubuntu" {
   project_id = data.gcore_project.pr.id
 }
 
+// pin by constraint instead of the exact name
+data "gcore_image" "ubuntu_latest" {
+  os_distro    = "ubuntu"
+  os_version   = "22.04"
+  visibility   = "public"
+  most_recent  = true
+  region_id    = data.gcore_region.rg.id
+  project_id   = data.gcore_project.pr.id
+}
+
 output "view" {
   value = data.gcore_image.ubuntu
 }
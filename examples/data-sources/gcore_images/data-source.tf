@@ -0,0 +1,23 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_images" "ubuntu" {
+  os_distro  = "ubuntu"
+  os_version = "22"
+  visibility = "public"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
+output "newest_ubuntu_id" {
+  value = data.gcore_images.ubuntu.ids[0]
+}
@@ -0,0 +1,22 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_instance_console_log" "boot" {
+  region_id   = data.gcore_region.rg.id
+  project_id  = data.gcore_project.pr.id
+  instance_id = gcore_instancev2.instance.id
+  lines       = 50
+}
+
+output "boot_log" {
+  value = data.gcore_instance_console_log.boot.output
+}
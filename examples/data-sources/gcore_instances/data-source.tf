@@ -0,0 +1,28 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_instances" "web" {
+  metadata_kv = {
+    role = "web"
+  }
+  status     = "ACTIVE"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
+resource "local_file" "inventory" {
+  filename = "inventory.ini"
+  content = join("\n", concat(["[web]"], [
+    for vm in data.gcore_instances.web.instances :
+    "${vm.name} ansible_host=${vm.primary_ipv4} region=${vm.region} flavor=${vm.flavor_id}"
+  ]))
+}
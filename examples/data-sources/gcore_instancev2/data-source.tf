@@ -0,0 +1,25 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_instancev2" "vm" {
+  name       = "test-vm"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
+output "private_interfaces" {
+  value = [for iface in data.gcore_instancev2.vm.interface : iface.ip_address if iface.fip_address == ""]
+}
+
+output "volume_sizes" {
+  value = { for v in data.gcore_instancev2.vm.volume : v.volume_id => v.size }
+}
@@ -0,0 +1,25 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_k8sv2_certificates" "certs" {
+  cluster_name       = "cluster1"
+  region_id          = data.gcore_region.rg.id
+  project_id         = data.gcore_project.pr.id
+}
+
+output "ca_expires_in_days" {
+  value = data.gcore_k8sv2_certificates.certs.expires_in_days
+}
+
+output "ca_rotation_status" {
+  value = data.gcore_k8sv2_certificates.certs.rotation_status
+}
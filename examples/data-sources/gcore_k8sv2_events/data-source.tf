@@ -0,0 +1,22 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_k8sv2_events" "events" {
+  cluster_name = "cluster1"
+  region_id    = data.gcore_region.rg.id
+  project_id   = data.gcore_project.pr.id
+  limit        = 10
+}
+
+output "failed_events" {
+  value = [for e in data.gcore_k8sv2_events.events.events : e if e.state == "ERROR"]
+}
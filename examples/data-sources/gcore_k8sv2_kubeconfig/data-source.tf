@@ -19,5 +19,15 @@ data "gcore_k8sv2_kubeconfig" "config" {
 // to store kubeconfig in a file pls use
 // terraform output -raw kubeconfig > config.yaml
 output "kubeconfig" {
-  value = data.gcore_k8sv2_kubeconfig.config.kubeconfig
+  value     = data.gcore_k8sv2_kubeconfig.config.kubeconfig
+  sensitive = true
+}
+
+provider "helm" {
+  kubernetes {
+    host                   = data.gcore_k8sv2_kubeconfig.config.host
+    cluster_ca_certificate = base64decode(data.gcore_k8sv2_kubeconfig.config.certificate_authority_data)
+    client_certificate     = base64decode(data.gcore_k8sv2_kubeconfig.config.client_certificate_data)
+    client_key             = base64decode(data.gcore_k8sv2_kubeconfig.config.client_key_data)
+  }
 }
@@ -0,0 +1,2 @@
+# import using <cdn_resource_id>,<cdn_resource_id>,... format, name is not stored by the API and is taken from the configuration
+terraform import gcore_cdn_rate_limiter_profile.profile 123,456
\ No newline at end of file
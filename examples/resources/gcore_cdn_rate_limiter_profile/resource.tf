@@ -0,0 +1,27 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_cdn_rate_limiter_profile" "api_limits" {
+  name         = "api limits"
+  rate         = 50
+  burst        = 100
+  rate_unit    = "r/s"
+  resource_ids = [gcore_cdn_resource.cdn_example_com.id, gcore_cdn_resource.api_example_com.id]
+}
+
+resource "gcore_cdn_resource" "cdn_example_com" {
+  cname        = "cdn.example.com"
+  origin_group = gcore_cdn_origingroup.origin_group_1.id
+
+  options {
+    edge_cache_settings {
+      default = "8d"
+    }
+  }
+
+  // request_limiter option is managed by gcore_cdn_rate_limiter_profile
+  lifecycle {
+    ignore_changes = [options[0].request_limiter]
+  }
+}
@@ -0,0 +1,34 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+// rules defined within the CDN resource are created in parallel
+resource "gcore_cdn_resource" "cdn_example_com" {
+  cname           = "cdn.example.com"
+  origin_group    = 1
+  origin_protocol = "MATCH"
+
+  rule {
+    name      = "All images"
+    rule      = "/folder/images/*.png"
+    rule_type = 0
+
+    options {
+      edge_cache_settings {
+        default = "14d"
+      }
+    }
+  }
+
+  rule {
+    name      = "API"
+    rule      = "/api/"
+    rule_type = 0
+
+    options {
+      edge_cache_settings {
+        enabled = false
+      }
+    }
+  }
+}
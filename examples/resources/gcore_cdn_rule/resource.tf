@@ -89,6 +89,20 @@ resource "gcore_cdn_rule" "cdn_example_com_rule_3" {
   }
 }
 
+resource "gcore_cdn_rule" "cdn_example_com_rule_4" {
+  resource_id = gcore_cdn_resource.cdn_example_com.id
+  name        = "Everything except API and admin"
+  patterns    = ["/api/", "/admin/"]
+  negate      = true
+  rule_type   = 0
+
+  options {
+    edge_cache_settings {
+      default = "1d"
+    }
+  }
+}
+
 resource "gcore_cdn_origingroup" "origin_group_1" {
   name     = "origin_group_1"
   use_next = true
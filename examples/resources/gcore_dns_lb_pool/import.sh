@@ -0,0 +1,2 @@
+# import using zone:domain:type format
+terraform import gcore_dns_lb_pool.www example.com:www.example.com:A
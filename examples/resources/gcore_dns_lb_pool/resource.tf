@@ -0,0 +1,40 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_dns_zone" "example" {
+  name = "example.com"
+}
+
+// two weighted primaries, a backup served only when both primaries are down
+resource "gcore_dns_lb_pool" "www" {
+  zone    = gcore_dns_zone.example.name
+  domain  = "www.${gcore_dns_zone.example.name}"
+  type    = "A"
+  ttl     = 60
+  answers = 1
+
+  member {
+    content = "192.0.2.1"
+    weight  = 3
+  }
+  member {
+    content = "192.0.2.2"
+    weight  = 1
+  }
+  member {
+    content = "198.51.100.1"
+    backup  = true
+  }
+
+  healthcheck {
+    protocol         = "HTTP"
+    port             = 443
+    tls              = true
+    method           = "GET"
+    url              = "/health"
+    http_status_code = 200
+    frequency        = 30
+    timeout          = 5
+  }
+}
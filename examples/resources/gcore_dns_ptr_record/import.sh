@@ -0,0 +1,2 @@
+# import using zone:ip_address format
+terraform import gcore_dns_ptr_record.mail 2.0.192.in-addr.arpa:192.0.2.10
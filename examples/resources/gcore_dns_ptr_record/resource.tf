@@ -0,0 +1,25 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_dns_zone" "reverse_zone" {
+  name = "2.0.192.in-addr.arpa"
+}
+
+// reverse record for an explicit address
+resource "gcore_dns_ptr_record" "mail" {
+  zone       = gcore_dns_zone.reverse_zone.name
+  ip_address = "192.0.2.10"
+  ptrdname   = "mail.example.com."
+  ttl        = 3600
+}
+
+// reverse record for a floating IP, zone is detected automatically
+resource "gcore_dns_ptr_record" "floating" {
+  project_id    = 1
+  region_id     = 1
+  floatingip_id = gcore_floatingip.fip.id
+  ptrdname      = "smtp.example.com."
+
+  depends_on = [gcore_dns_zone.reverse_zone]
+}
@@ -0,0 +1,29 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+variable "live" {
+  description = "Environment receiving the traffic, blue or green"
+  default     = "blue"
+}
+
+resource "gcore_dns_switchover" "cutover" {
+  zone     = "example.com"
+  position = var.live
+
+  record {
+    domain = "www.example.com"
+    type   = "A"
+    ttl    = 60
+    blue   = ["192.0.2.10", "192.0.2.11"]
+    green  = ["198.51.100.10", "198.51.100.11"]
+  }
+
+  record {
+    domain = "api.example.com"
+    type   = "CNAME"
+    ttl    = 60
+    blue   = ["blue-lb.example.net."]
+    green  = ["green-lb.example.net."]
+  }
+}
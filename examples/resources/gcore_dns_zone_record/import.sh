@@ -1,2 +1,3 @@
 # import using zone:domain:type format
-terraform import gcore_dns_zone_record.example_rrset0 example.com:domain.example.com:A
\ No newline at end of file
+terraform import gcore_dns_zone_record.example_rrset0 example.com:domain.example.com:A
+# to adopt all RRSets of a zone at once use import blocks with ids from gcore_dns_zone_export data source
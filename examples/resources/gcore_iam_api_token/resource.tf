@@ -0,0 +1,15 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_iam_api_token" "ci" {
+  name        = "ci"
+  description = "Token for CI pipelines"
+  role        = "Engineers"
+  expires_at  = "2027-01-01T00:00:00Z"
+}
+
+output "ci_token" {
+  value     = gcore_iam_api_token.ci.token
+  sensitive = true
+}
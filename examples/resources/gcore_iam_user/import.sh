@@ -0,0 +1,2 @@
+# import using <user_id> format
+terraform import gcore_iam_user.engineer 123
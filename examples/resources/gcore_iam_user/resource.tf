@@ -0,0 +1,19 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_iam_user" "engineer" {
+  email = "engineer@example.com"
+  name  = "Engineer"
+  role  = "Engineers"
+
+  project_role {
+    project_id = 1
+    role       = "ProjectAdministrator"
+  }
+
+  project_role {
+    project_id = 2
+    role       = "Observer"
+  }
+}
@@ -0,0 +1,36 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_instancev2" "vm" {
+  # ...
+
+  lifecycle {
+    # interfaces attached with gcore_instance_interface are read by the instance resource too
+    ignore_changes = [interface]
+  }
+}
+
+resource "gcore_instance_interface" "blue" {
+  project_id  = 1
+  region_id   = 1
+  instance_id = gcore_instancev2.vm.id
+
+  name            = "blue"
+  type            = "subnet"
+  network_id      = "ddf9e5e0-9c5f-4b5c-8a3b-1e1f6c3d8a10"
+  subnet_id       = "6f2d5b0e-5c8c-4d44-9f52-4c1c2dfe1b0a"
+  security_groups = ["a8a6c1f2-3b0d-4c5e-9c7d-2e0f1b3a4c5d"]
+}
+
+resource "gcore_instance_interface" "public" {
+  project_id  = 1
+  region_id   = 1
+  instance_id = gcore_instancev2.vm.id
+
+  name            = "public"
+  type            = "reserved_fixed_ip"
+  port_id         = "b1f2d3c4-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+  fip_source      = "existing"
+  existing_fip_id = "c2d3e4f5-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+}
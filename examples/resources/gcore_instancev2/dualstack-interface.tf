@@ -21,4 +21,8 @@ resource "gcore_instancev2" "instance_with_dualstack" {
 
 output "addresses" {
   value = gcore_instancev2.instance_with_dualstack.addresses
-}
\ No newline at end of file
+}
+
+output "ipv6_address" {
+  value = one(gcore_instancev2.instance_with_dualstack.interface[*].ipv6_address)
+}
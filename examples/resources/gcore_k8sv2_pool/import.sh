@@ -0,0 +1,4 @@
+# import using <project_id>:<region_id>:<cluster_name>:<pool_name> format
+# the import doesn't change the pool, move it from the pool list of gcore_k8sv2 to its separate_pools
+# before the import so that the cluster stops managing it
+terraform import gcore_k8sv2_pool.pool1 1:6:cluster1:pool1
@@ -0,0 +1,29 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+locals {
+  gpu_pools = {
+    "gpu-a" = 1
+    "gpu-b" = 2
+  }
+}
+
+resource "gcore_k8sv2_pool" "gpu" {
+  for_each = local.gpu_pools
+
+  project_id     = 1
+  region_id      = 1
+  cluster_name   = gcore_k8sv2.cl.name
+  name           = each.key
+  flavor_id      = "bm3-ai-1xlarge-h100-80-8"
+  min_node_count = each.value
+  max_node_count = each.value
+
+  labels = {
+    "nvidia.com/gpu.present" = "true"
+  }
+  taints = {
+    "nvidia.com/gpu" = "true:NoSchedule"
+  }
+}
@@ -0,0 +1,22 @@
+resource "gcore_lblistener" "proxyv2_8443" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+
+  name          = "My first proxy v2 listener with pool"
+  protocol      = "TCP"
+  protocol_port = 8443
+}
+
+resource "gcore_lbpool" "proxyv2_8443" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+  listener_id     = gcore_lblistener.proxyv2_8443.id
+
+  name            = "My first proxy v2 pool"
+  protocol        = "PROXYV2"
+  lb_algorithm    = "ROUND_ROBIN"
+}
@@ -0,0 +1,29 @@
+resource "gcore_lblistener" "udp_53" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+
+  name          = "My first udp listener with pool"
+  protocol      = "UDP"
+  protocol_port = 53
+}
+
+resource "gcore_lbpool" "udp_53" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+  listener_id     = gcore_lblistener.udp_53.id
+
+  name            = "My first udp pool"
+  protocol        = "UDP"
+  lb_algorithm    = "SOURCE_IP"
+
+  health_monitor {
+    type        = "UDP-CONNECT"
+    delay       = 10
+    max_retries = 3
+    timeout     = 5
+  }
+}
@@ -0,0 +1,22 @@
+resource "gcore_loadbalancerv2" "public_lb_restricted" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+  name       = "My public load balancer reachable from the office only"
+  flavor     = "lb1-1-2"
+
+  security_group_rules {
+    direction        = "ingress"
+    ethertype        = "IPv4"
+    protocol         = "tcp"
+    port_range_min   = 443
+    port_range_max   = 443
+    remote_ip_prefix = "203.0.113.0/24"
+    description      = "office"
+  }
+
+  security_group_rules {
+    direction = "egress"
+    ethertype = "IPv4"
+    protocol  = "any"
+  }
+}
@@ -0,0 +1,108 @@
+package gcore
+
+import (
+	"sort"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// aiClusterNodeAddressesSchema is node_addresses of gcore_ai_cluster resource and data source
+func aiClusterNodeAddressesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Addresses of the cluster nodes ordered by node name, for example to generate an Ansible inventory",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"instance_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"internal_ipv4": {
+					Type:        schema.TypeList,
+					Description: "Private IPv4 addresses of the node",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"internal_ipv6": {
+					Type:        schema.TypeList,
+					Description: "Private IPv6 addresses of the node",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"external_ipv4": {
+					Type:        schema.TypeList,
+					Description: "Public and floating IPv4 addresses of the node",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"external_ipv6": {
+					Type:        schema.TypeList,
+					Description: "Public IPv6 addresses of the node",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// flattenAIClusterNodeAddresses returns node_addresses of the cluster nodes, nodes are ordered by name and
+// addresses of a node by network name, so that the order doesn't change between reads.
+func flattenAIClusterNodeAddresses(servers []instances.Instance) []map[string]interface{} {
+	sorted := make([]instances.Instance, len(servers))
+	copy(sorted, servers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	nodes := make([]map[string]interface{}, 0, len(sorted))
+	for _, instance := range sorted {
+		networks := make([]string, 0, len(instance.Addresses))
+		for name := range instance.Addresses {
+			networks = append(networks, name)
+		}
+		sort.Strings(networks)
+
+		internalV4, internalV6 := make([]string, 0), make([]string, 0)
+		externalV4, externalV6 := make([]string, 0), make([]string, 0)
+		for _, name := range networks {
+			for _, addr := range instance.Addresses[name] {
+				ip := addr.Address
+				if ip == nil {
+					continue
+				}
+				external := addr.Type == types.AddressTypeFloating || (ip.IsGlobalUnicast() && !ip.IsPrivate())
+				switch {
+				case ip.To4() != nil && external:
+					externalV4 = append(externalV4, ip.String())
+				case ip.To4() != nil:
+					internalV4 = append(internalV4, ip.String())
+				case external:
+					externalV6 = append(externalV6, ip.String())
+				default:
+					internalV6 = append(internalV6, ip.String())
+				}
+			}
+		}
+
+		nodes = append(nodes, map[string]interface{}{
+			"instance_id":   instance.ID,
+			"instance_name": instance.Name,
+			"internal_ipv4": internalV4,
+			"internal_ipv6": internalV6,
+			"external_ipv4": externalV4,
+			"external_ipv6": externalV6,
+		})
+	}
+	return nodes
+}
@@ -0,0 +1,64 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+)
+
+func TestFlattenAIClusterNodeAddresses(t *testing.T) {
+	servers := []instances.Instance{
+		{
+			ID:   "2",
+			Name: "gpu-node-2",
+			Addresses: map[string][]instances.InstanceAddress{
+				"training": {{Type: types.AddressTypeFixed, Address: net.ParseIP("10.0.0.2")}},
+			},
+		},
+		{
+			ID:   "1",
+			Name: "gpu-node-1",
+			Addresses: map[string][]instances.InstanceAddress{
+				"training": {{Type: types.AddressTypeFixed, Address: net.ParseIP("10.0.0.1")}},
+				"external": {
+					{Type: types.AddressTypeFixed, Address: net.ParseIP("203.0.113.10")},
+					{Type: types.AddressTypeFixed, Address: net.ParseIP("2001:db8::10")},
+				},
+				"management": {
+					{Type: types.AddressTypeFixed, Address: net.ParseIP("192.168.1.1")},
+					{Type: types.AddressTypeFloating, Address: net.ParseIP("192.168.100.1")},
+					{Type: types.AddressTypeFixed, Address: net.ParseIP("fd00::1")},
+				},
+			},
+		},
+	}
+
+	got := flattenAIClusterNodeAddresses(servers)
+	want := []map[string]interface{}{
+		{
+			"instance_id":   "1",
+			"instance_name": "gpu-node-1",
+			"internal_ipv4": []string{"192.168.1.1", "10.0.0.1"},
+			"internal_ipv6": []string{"fd00::1"},
+			"external_ipv4": []string{"203.0.113.10", "192.168.100.1"},
+			"external_ipv6": []string{"2001:db8::10"},
+		},
+		{
+			"instance_id":   "2",
+			"instance_name": "gpu-node-2",
+			"internal_ipv4": []string{"10.0.0.2"},
+			"internal_ipv6": []string{},
+			"external_ipv4": []string{},
+			"external_ipv6": []string{},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenAIClusterNodeAddresses() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/aiflavors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const aiClusterCapacityPollInterval = 30 * time.Second
+
+// aiClusterQuotaKeys are regional quotas consumed by a single node of the AI cluster, the first one reported by
+// the API for the region is checked
+var aiClusterQuotaKeys = map[bool][]string{
+	true:  {"baremetal_gpu_count", "baremetal_count"},
+	false: {"gpu_virtual_count", "gpu_count"},
+}
+
+// errAIClusterCapacity is returned when flavor is available but has no free capacity at the moment
+type errAIClusterCapacity struct {
+	flavor string
+}
+
+func (e errAIClusterCapacity) Error() string {
+	return fmt.Sprintf("flavor %s has no free capacity in the region at the moment, try another region or flavor, "+
+		"or set capacity_wait_timeout to wait for capacity", e.flavor)
+}
+
+// checkAIClusterCapacity checks that the flavor is available in the region and the account has enough quota to
+// launch the cluster. Capacity shortage is retried until capacity_wait_timeout expires, other problems fail at once.
+func checkAIClusterCapacity(ctx context.Context, provider *gcorecloud.ProviderClient, d *schema.ResourceData, flavor string) error {
+	if !d.Get("capacity_check").(bool) {
+		return nil
+	}
+	log.Printf("[DEBUG] Start AI cluster capacity checking for flavor %s", flavor)
+
+	flavorsClient, err := CreateClient(provider, d, AIFlavorsPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	if err := checkAIClusterQuota(provider, d, flavor); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(time.Duration(d.Get("capacity_wait_timeout").(int)) * time.Second)
+	for {
+		flavors, err := aiflavors.ListAll(flavorsClient, aiflavors.AIFlavorListOpts{IncludeCapacity: true})
+		if err != nil {
+			return fmt.Errorf("cannot get AI flavors: %w", err)
+		}
+		err = aiClusterFlavorCapacity(flavors, flavor)
+		if _, ok := err.(errAIClusterCapacity); !ok || time.Now().Add(aiClusterCapacityPollInterval).After(deadline) {
+			log.Println("[DEBUG] Finish AI cluster capacity checking")
+			return err
+		}
+		log.Printf("[DEBUG] Wait %s for capacity of flavor %s", aiClusterCapacityPollInterval, flavor)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cannot wait for capacity before timeout: %w", err)
+		case <-time.After(aiClusterCapacityPollInterval):
+		}
+	}
+}
+
+// aiClusterFlavorCapacity checks that the flavor is listed, enabled and has capacity for at least one node
+func aiClusterFlavorCapacity(flavors []aiflavors.AIFlavor, flavor string) error {
+	available := make([]string, 0, len(flavors))
+	for _, f := range flavors {
+		if f.FlavorID != flavor && f.FlavorName != flavor {
+			if !f.Disabled {
+				available = append(available, f.FlavorID)
+			}
+			continue
+		}
+		if f.Disabled {
+			return fmt.Errorf("flavor %s is disabled in the region, choose one of: %s", flavor, strings.Join(available, ", "))
+		}
+		if f.Capacity != nil && *f.Capacity < 1 {
+			return errAIClusterCapacity{flavor: flavor}
+		}
+		return nil
+	}
+	return fmt.Errorf("flavor %s is not available in the region, choose one of: %s", flavor, strings.Join(available, ", "))
+}
+
+// checkAIClusterQuota checks that regional quota of the account allows one more node of the flavor
+func checkAIClusterQuota(provider *gcorecloud.ProviderClient, d *schema.ResourceData, flavor string) error {
+	regionID, err := GetRegion(provider, d.Get("region_id").(int), d.Get("region_name").(string))
+	if err != nil {
+		return err
+	}
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    clientQuotasPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV2,
+	})
+	if err != nil {
+		return err
+	}
+
+	var quotas clientQuotas
+	if _, err := client.Get(client.ResourceBaseURL(), &quotas, nil); err != nil {
+		return fmt.Errorf("cannot get client quotas: %w", err)
+	}
+	for _, rq := range quotas.RegionalQuotas {
+		if rq["region_id"] == regionID {
+			return aiClusterQuota(rq, flavor)
+		}
+	}
+	return nil
+}
+
+// aiClusterQuota returns error if regional quotas don't allow one more node of the flavor
+func aiClusterQuota(regionalQuotas map[string]int, flavor string) error {
+	for _, key := range aiClusterQuotaKeys[isBmFlavor(flavor)] {
+		limit, ok := regionalQuotas[key+"_limit"]
+		if !ok {
+			continue
+		}
+		usage := regionalQuotas[key+"_usage"]
+		if limit-usage < 1 {
+			return fmt.Errorf("quota %s is exhausted in the region (limit %d, usage %d), "+
+				"request a quota increase or free up resources before launching flavor %s", key, limit, usage, flavor)
+		}
+		return nil
+	}
+	return nil
+}
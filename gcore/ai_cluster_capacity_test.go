@@ -0,0 +1,60 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/aiflavors"
+)
+
+func TestAIClusterFlavorCapacity(t *testing.T) {
+	none, some := 0, 2
+	flavors := []aiflavors.AIFlavor{
+		{FlavorID: "bm3-ai-1xlarge-h100-80-8", Capacity: &some},
+		{FlavorID: "bm3-ai-1xlarge-a100-80-8", Capacity: &none},
+		{FlavorID: "bm1-ai-2xsmall-v1pod-4", Disabled: true},
+		{FlavorID: "bm1-infrastructure-small"},
+	}
+
+	if err := aiClusterFlavorCapacity(flavors, "bm3-ai-1xlarge-h100-80-8"); err != nil {
+		t.Errorf("flavor with capacity got error: %s", err)
+	}
+	if err := aiClusterFlavorCapacity(flavors, "bm1-infrastructure-small"); err != nil {
+		t.Errorf("flavor without reported capacity got error: %s", err)
+	}
+	if _, ok := aiClusterFlavorCapacity(flavors, "bm3-ai-1xlarge-a100-80-8").(errAIClusterCapacity); !ok {
+		t.Error("flavor without capacity must return capacity error")
+	}
+	for _, flavor := range []string{"bm1-ai-2xsmall-v1pod-4", "unknown"} {
+		err := aiClusterFlavorCapacity(flavors, flavor)
+		if err == nil {
+			t.Errorf("flavor %s got no error", flavor)
+			continue
+		}
+		if _, ok := err.(errAIClusterCapacity); ok {
+			t.Errorf("flavor %s must fail without waiting for capacity", flavor)
+		}
+	}
+}
+
+func TestAIClusterQuota(t *testing.T) {
+	tests := []struct {
+		quotas  map[string]int
+		flavor  string
+		wantErr bool
+	}{
+		{map[string]int{"baremetal_gpu_count_limit": 2, "baremetal_gpu_count_usage": 1}, "bm3-ai-1xlarge-h100-80-8", false},
+		{map[string]int{"baremetal_gpu_count_limit": 2, "baremetal_gpu_count_usage": 2}, "bm3-ai-1xlarge-h100-80-8", true},
+		{map[string]int{"baremetal_count_limit": 0}, "bm3-ai-1xlarge-h100-80-8", true},
+		{map[string]int{"gpu_virtual_count_limit": 0}, "bm3-ai-1xlarge-h100-80-8", false},
+		{map[string]int{"gpu_virtual_count_limit": 0}, "g2a-ai-fake-v1pod-8", true},
+		{map[string]int{}, "g2a-ai-fake-v1pod-8", false},
+	}
+	for _, tt := range tests {
+		if err := aiClusterQuota(tt.quotas, tt.flavor); (err != nil) != tt.wantErr {
+			t.Errorf("aiClusterQuota(%v, %q) error = %v, wantErr %v", tt.quotas, tt.flavor, err, tt.wantErr)
+		}
+	}
+}
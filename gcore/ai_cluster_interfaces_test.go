@@ -0,0 +1,105 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	ai "github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/ais"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestAIClusterInterfaceFromPort(t *testing.T) {
+	port := ai.Interface{
+		PortID:        "port-id",
+		NetworkID:     "network-id",
+		IPAssignments: []instances.PortIP{{IPAddress: net.ParseIP("192.0.2.10"), SubnetID: "subnet-b"}},
+		NetworkDetails: instances.NetworkDetail{
+			Subnets: []instances.Subnet{{ID: "subnet-a"}, {ID: "subnet-b"}},
+		},
+	}
+	anySubnet := map[string]interface{}{"type": "any_subnet", "network_id": "network-id", "subnet_id": "", "port_id": ""}
+	subnet := map[string]interface{}{"type": "subnet", "network_id": "", "subnet_id": "subnet-b", "port_id": ""}
+	// interface configured at the same position doesn't matter
+	reservedElsewhere := map[string]interface{}{"type": "reserved_fixed_ip", "network_id": "", "subnet_id": "", "port_id": "other-port"}
+
+	tests := []struct {
+		name       string
+		iface      ai.Interface
+		reserved   bool
+		configured []interface{}
+		want       ai.AIClusterInterface
+	}{
+		{
+			name:       "subnet of the port",
+			iface:      port,
+			configured: []interface{}{reservedElsewhere, subnet},
+			want:       ai.AIClusterInterface{Type: string(types.SubnetInterfaceType), SubnetID: "subnet-b"},
+		},
+		{
+			name:       "any subnet of the network",
+			iface:      port,
+			configured: []interface{}{reservedElsewhere, anySubnet},
+			want:       ai.AIClusterInterface{Type: string(types.AnySubnetInterfaceType), NetworkID: "network-id"},
+		},
+		{
+			name:       "reserved fixed ip",
+			iface:      port,
+			reserved:   true,
+			configured: []interface{}{subnet},
+			want:       ai.AIClusterInterface{Type: string(types.ReservedFixedIpType), PortID: "port-id"},
+		},
+		{
+			name:  "external",
+			iface: ai.Interface{PortID: "port-id", NetworkDetails: instances.NetworkDetail{External: true}},
+			want:  ai.AIClusterInterface{Type: string(types.ExternalInterfaceType)},
+		},
+		{
+			name:  "imported",
+			iface: port,
+			want:  ai.AIClusterInterface{Type: string(types.SubnetInterfaceType), SubnetID: "subnet-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aiClusterInterfaceFromPort(tt.iface, tt.reserved, tt.configured); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aiClusterInterfaceFromPort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAIClusterSecurityGroupsConflict(t *testing.T) {
+	sgType := cty.Set(cty.Object(map[string]cty.Type{"id": cty.String}))
+	ifaceType := cty.Object(map[string]cty.Type{"security_groups": cty.List(cty.String)})
+	config := func(sgs, ifaceSGs cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"security_group": sgs,
+			"interface":      cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"security_groups": ifaceSGs})}),
+		})
+	}
+	clusterSGs := cty.SetVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("sg-1")})})
+	ifaceSGs := cty.ListVal([]cty.Value{cty.StringVal("sg-2")})
+
+	tests := []struct {
+		name   string
+		config cty.Value
+		want   bool
+	}{
+		{"both", config(clusterSGs, ifaceSGs), true},
+		{"cluster only", config(clusterSGs, cty.NullVal(cty.List(cty.String))), false},
+		{"interface only", config(cty.NullVal(sgType), ifaceSGs), false},
+		{"interface unknown", config(clusterSGs, cty.UnknownVal(cty.List(cty.String))), true},
+		{"null config", cty.NullVal(cty.Object(map[string]cty.Type{"interface": cty.List(ifaceType)})), false},
+	}
+	for _, tt := range tests {
+		if got := aiClusterSecurityGroupsConflict(tt.config); got != tt.want {
+			t.Errorf("%s: aiClusterSecurityGroupsConflict() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
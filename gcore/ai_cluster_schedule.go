@@ -0,0 +1,117 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aiClusterWindowTimeRegexp = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+// aiClusterWeekdays are values of suspend_window.days
+var aiClusterWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// aiClusterNow returns the time suspend_window is checked against
+var aiClusterNow = time.Now
+
+// validateAIClusterSuspendWindow checks that suspend_window is not empty
+func validateAIClusterSuspendWindow(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	windows := diff.Get("suspend_window").([]interface{})
+	if len(windows) == 0 || windows[0] == nil {
+		return nil
+	}
+	window := windows[0].(map[string]interface{})
+	start, end := window["start"].(string), window["end"].(string)
+	if start != "" && start == end {
+		return fmt.Errorf("suspend_window: start and end must differ, got %s", start)
+	}
+	return nil
+}
+
+// suppressAIClusterStatusInSuspendWindow keeps a cluster suspended outside of terraform from being resumed
+// while inside suspend_window. The platform has no scheduling API, so the provider doesn't suspend the cluster itself.
+func suppressAIClusterStatusInSuspendWindow(_, old, new string, d *schema.ResourceData) bool {
+	if !strings.EqualFold(old, SuspendedStatus) || !strings.EqualFold(new, ActiveStatus) {
+		return false
+	}
+	windows := d.Get("suspend_window").([]interface{})
+	if len(windows) == 0 || windows[0] == nil {
+		return false
+	}
+	inside, err := aiClusterInSuspendWindow(aiClusterNow(), windows[0].(map[string]interface{}))
+	if err != nil {
+		log.Printf("[WARN] Cannot check suspend_window of AI cluster %s: %s", d.Id(), err)
+		return false
+	}
+	if inside {
+		log.Printf("[DEBUG] AI cluster %s is kept suspended inside suspend_window", d.Id())
+	}
+	return inside
+}
+
+// aiClusterInSuspendWindow returns true if now is inside the window. Window ending before it starts ends on the next
+// day, days of the window refer to the day the window starts.
+func aiClusterInSuspendWindow(now time.Time, window map[string]interface{}) (bool, error) {
+	loc, err := time.LoadLocation(window["timezone"].(string))
+	if err != nil {
+		return false, fmt.Errorf("suspend_window: %w", err)
+	}
+	start, err := parseAIClusterWindowTime(window["start"].(string))
+	if err != nil {
+		return false, err
+	}
+	end, err := parseAIClusterWindowTime(window["end"].(string))
+	if err != nil {
+		return false, err
+	}
+
+	local := now.In(loc)
+	current := local.Hour()*60 + local.Minute()
+	startDay := local.Weekday()
+	var inside bool
+	switch {
+	case start < end:
+		inside = current >= start && current < end
+	case current >= start:
+		inside = true
+	case current < end:
+		inside = true
+		startDay = local.AddDate(0, 0, -1).Weekday()
+	}
+	if !inside {
+		return false, nil
+	}
+
+	days, _ := window["days"].(*schema.Set)
+	if days == nil || days.Len() == 0 {
+		return true, nil
+	}
+	for _, day := range days.List() {
+		if aiClusterWeekdays[strings.ToLower(day.(string))] == startDay {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseAIClusterWindowTime returns minutes since midnight of HH:MM time
+func parseAIClusterWindowTime(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("suspend_window: time %q must be in HH:MM format", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
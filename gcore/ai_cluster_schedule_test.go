@@ -0,0 +1,81 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAIClusterInSuspendWindow(t *testing.T) {
+	overnight := map[string]interface{}{"start": "22:00", "end": "06:30", "timezone": "Europe/Luxembourg"}
+	workdays := map[string]interface{}{
+		"start":    "20:00",
+		"end":      "08:00",
+		"timezone": "UTC",
+		"days":     schema.NewSet(schema.HashString, []interface{}{"mon", "tue", "wed", "thu", "fri"}),
+	}
+	daytime := map[string]interface{}{"start": "09:00", "end": "17:00", "timezone": "UTC"}
+
+	tests := []struct {
+		name   string
+		window map[string]interface{}
+		now    string
+		want   bool
+	}{
+		{"overnight before start", overnight, "2024-03-12T20:59:00Z", false},
+		{"overnight after start in local time", overnight, "2024-03-12T21:00:00Z", true},
+		{"overnight next morning", overnight, "2024-03-13T05:29:00Z", true},
+		{"overnight after end", overnight, "2024-03-13T05:30:00Z", false},
+		{"friday night", workdays, "2024-03-15T23:00:00Z", true},
+		{"saturday morning of friday window", workdays, "2024-03-16T07:00:00Z", true},
+		{"saturday night", workdays, "2024-03-16T23:00:00Z", false},
+		{"monday morning of sunday window", workdays, "2024-03-18T07:00:00Z", false},
+		{"daytime", daytime, "2024-03-12T12:00:00Z", true},
+		{"daytime end", daytime, "2024-03-12T17:00:00Z", false},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		got, err := aiClusterInSuspendWindow(now, tt.window)
+		if err != nil {
+			t.Fatalf("%s: aiClusterInSuspendWindow() error = %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: aiClusterInSuspendWindow() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSuppressAIClusterStatusInSuspendWindow(t *testing.T) {
+	defer func(now func() time.Time) { aiClusterNow = now }(aiClusterNow)
+	aiClusterNow = func() time.Time { return time.Date(2024, 3, 12, 23, 0, 0, 0, time.UTC) }
+
+	window := []interface{}{map[string]interface{}{"start": "22:00", "end": "06:00", "timezone": "UTC"}}
+	withWindow := schema.TestResourceDataRaw(t, resourceAICluster().Schema, map[string]interface{}{"suspend_window": window})
+	withoutWindow := schema.TestResourceDataRaw(t, resourceAICluster().Schema, map[string]interface{}{})
+
+	tests := []struct {
+		name     string
+		d        *schema.ResourceData
+		old, new string
+		want     bool
+	}{
+		{"suspended inside window", withWindow, SuspendedStatus, ActiveStatus, true},
+		{"suspended without window", withoutWindow, SuspendedStatus, ActiveStatus, false},
+		{"suspend inside window", withWindow, ActiveStatus, SuspendedStatus, false},
+		{"status in other case", withWindow, "Suspended", "Active", true},
+	}
+	for _, tt := range tests {
+		if got := suppressAIClusterStatusInSuspendWindow("cluster_status", tt.old, tt.new, tt.d); got != tt.want {
+			t.Errorf("%s: suppressAIClusterStatusInSuspendWindow() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	aiClusterNow = func() time.Time { return time.Date(2024, 3, 12, 12, 0, 0, 0, time.UTC) }
+	if suppressAIClusterStatusInSuspendWindow("cluster_status", SuspendedStatus, ActiveStatus, withWindow) {
+		t.Errorf("suppressAIClusterStatusInSuspendWindow() = true outside of the window, want false")
+	}
+}
@@ -0,0 +1,132 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const clientQuotasPoint = "client_quotas"
+
+type clientQuotas struct {
+	GlobalQuotas   map[string]int   `json:"global_quotas"`
+	RegionalQuotas []map[string]int `json:"regional_quotas"`
+}
+
+type clientServiceStatus struct {
+	Enabled bool   `json:"enabled"`
+	Status  string `json:"status"`
+}
+
+type clientAccount struct {
+	ID              int                            `json:"id"`
+	ServiceStatuses map[string]clientServiceStatus `json:"serviceStatuses"`
+}
+
+func dataSourceAccountLimits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountLimitsRead,
+		Description: "Represent account quotas (limits and usage) and enabled services. It allows to check the account before creating resources instead of failing on API errors.",
+		Schema: map[string]*schema.Schema{
+			"region_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Region ID to read regional quotas for",
+			},
+			"client_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Account ID",
+			},
+			"global_quotas": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Account-wide quotas, eg. project_count_limit and project_count_usage",
+			},
+			"regional_quotas": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Quotas of the region set with region_id, eg. gpu_count_limit and gpu_count_usage",
+			},
+			"services": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+				Description: "Services of the account and whether they are enabled, eg. CDN, CLOUD, DNS, STORAGE, WAAP, FASTEDGE",
+			},
+		},
+	}
+}
+
+func dataSourceAccountLimitsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Account Limits reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    clientQuotasPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV2,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var quotas clientQuotas
+	if _, err := client.Get(client.ResourceBaseURL(), &quotas, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get client quotas: %w", err))
+	}
+
+	var account clientAccount
+	accountURL := strings.TrimSuffix(config.PlatformAPI, "/") + "/clients/me"
+	if _, err := provider.Request(http.MethodGet, accountURL, &gcorecloud.RequestOpts{
+		JSONResponse: &account,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get account: %w", err))
+	}
+
+	regionalQuotas := make(map[string]int)
+	if regionID, ok := d.GetOk("region_id"); ok {
+		found := false
+		for _, rq := range quotas.RegionalQuotas {
+			if rq["region_id"] == regionID.(int) {
+				for k, v := range rq {
+					if k != "region_id" {
+						regionalQuotas[k] = v
+					}
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return diag.Errorf("quotas for region %d not found", regionID.(int))
+		}
+	}
+
+	services := make(map[string]bool, len(account.ServiceStatuses))
+	for name, status := range account.ServiceStatuses {
+		services[name] = status.Enabled
+	}
+
+	d.SetId(strconv.Itoa(account.ID))
+	d.Set("client_id", account.ID)
+	d.Set("global_quotas", quotas.GlobalQuotas)
+	d.Set("regional_quotas", regionalQuotas)
+	d.Set("services", services)
+
+	log.Println("[DEBUG] Finish Account Limits reading")
+	return nil
+}
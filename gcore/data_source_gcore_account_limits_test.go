@@ -0,0 +1,35 @@
+//go:build cloud
+// +build cloud
+
+package gcore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAccountLimitsDataSource(t *testing.T) {
+	fullName := "data.gcore_account_limits.acctest"
+	tpl := fmt.Sprintf(`
+			data "gcore_account_limits" "acctest" {
+              %s
+			}
+		`, regionInfo())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tpl,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttrSet(fullName, "client_id"),
+					resource.TestCheckResourceAttrSet(fullName, "global_quotas.%"),
+				),
+			},
+		},
+	})
+}
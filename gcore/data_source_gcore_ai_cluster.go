@@ -12,6 +12,7 @@ import (
 	ai "github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/ais"
 	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
 	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -272,6 +273,12 @@ func dataSourceAICluster() *schema.Resource {
 							Description: "Network ID the subnet belongs to. Port will be plugged in this network",
 							Computed:    true,
 						},
+						"security_groups": {
+							Type:        schema.TypeList,
+							Description: "Security group IDs of the interface ports",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -295,6 +302,7 @@ func dataSourceAICluster() *schema.Resource {
 				Description: "A password for baremetal instance. This parameter is used to set a password for the Admin user on a Windows instance, a default user or a new user on a Linux instance",
 				Computed:    true,
 			},
+			"node_addresses": aiClusterNodeAddressesSchema(),
 			"poplar_servers": {
 				Type:        schema.TypeList,
 				Description: "Poplar servers",
@@ -496,6 +504,46 @@ func flattenSecurityGroup(securityGroups []ai.PoplarInterfaceSecGrop) []interfac
 	return sgIDs
 }
 
+// aiClusterInterfaceFromPort returns the interface of the cluster port. The port doesn't tell whether its subnet
+// was picked by any_subnet, so any_subnet is kept when it is configured for the port network and no subnet
+// interface is configured for the port subnet.
+func aiClusterInterfaceFromPort(iface ai.Interface, reserved bool, configured []interface{}) ai.AIClusterInterface {
+	switch {
+	case iface.NetworkDetails.External:
+		return ai.AIClusterInterface{Type: string(types.ExternalInterfaceType)}
+	case reserved:
+		return ai.AIClusterInterface{Type: string(types.ReservedFixedIpType), PortID: iface.PortID}
+	}
+
+	var subnetID string
+	if len(iface.IPAssignments) > 0 {
+		subnetID = iface.IPAssignments[0].SubnetID
+	} else if len(iface.NetworkDetails.Subnets) > 0 {
+		subnetID = iface.NetworkDetails.Subnets[0].ID
+	}
+	anySubnet := false
+	for _, raw := range configured {
+		ifaceMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch types.InterfaceType(ifaceMap["type"].(string)) {
+		case types.SubnetInterfaceType:
+			if ifaceMap["subnet_id"].(string) == subnetID {
+				return ai.AIClusterInterface{Type: string(types.SubnetInterfaceType), SubnetID: subnetID}
+			}
+		case types.AnySubnetInterfaceType:
+			if ifaceMap["network_id"].(string) == iface.NetworkID {
+				anySubnet = true
+			}
+		}
+	}
+	if anySubnet {
+		return ai.AIClusterInterface{Type: string(types.AnySubnetInterfaceType), NetworkID: iface.NetworkID}
+	}
+	return ai.AIClusterInterface{Type: string(types.SubnetInterfaceType), SubnetID: subnetID}
+}
+
 func flattenInterfaces(interfaces []ai.AIClusterInterface) []map[string]interface{} {
 	clusterInterfaces := make([]map[string]interface{}, len(interfaces))
 	for index, iface := range interfaces {
@@ -653,23 +701,39 @@ func setAIClusterResourcerData(d *schema.ResourceData, provider *gcorecloud.Prov
 	if err != nil {
 		return err
 	}
+	rfipClient, err := CreateClient(provider, d, reservedFixedIPsPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	configuredIfaces, _ := d.Get("interface").([]interface{})
 	aiClusterInterfaces := make([]ai.AIClusterInterface, len(clusterInterfaces))
 	for ifaceIndex, iface := range clusterInterfaces {
-		if iface.NetworkDetails.External {
-			aiClusterInterfaces[ifaceIndex] = ai.AIClusterInterface{
-				Type: string(types.ExternalInterfaceType),
-			}
-		} else {
-			aiClusterInterfaces[ifaceIndex] = ai.AIClusterInterface{
-				Type:     string(types.SubnetInterfaceType),
-				SubnetID: iface.NetworkDetails.Subnets[0].ID,
+		reserved := !iface.NetworkDetails.External
+		if reserved {
+			if _, err := reservedfixedips.Get(rfipClient, iface.PortID).Extract(); err != nil {
+				switch err.(type) {
+				case gcorecloud.ErrDefault404:
+					reserved = false
+				default:
+					return err
+				}
 			}
 		}
+		aiClusterInterfaces[ifaceIndex] = aiClusterInterfaceFromPort(iface, reserved, configuredIfaces)
 	}
 
-	d.Set("interface", flattenInterfaces(aiClusterInterfaces))
+	portSecurityGroups := make(map[string][]string, len(cluster.SecurityGroups))
+	for _, portSG := range cluster.SecurityGroups {
+		portSecurityGroups[portSG.PortID] = portSG.SecurityGroups
+	}
+	ifaces := flattenInterfaces(aiClusterInterfaces)
+	for ifaceIndex, iface := range clusterInterfaces {
+		ifaces[ifaceIndex]["security_groups"] = portSecurityGroups[iface.PortID]
+	}
+	d.Set("interface", ifaces)
 	d.Set("cluster_metadata", cluster.Metadata)
 	d.Set("poplar_servers", flattenPoplarServers(cluster.PoplarServer))
+	d.Set("node_addresses", flattenAIClusterNodeAddresses(cluster.PoplarServer))
 
 	volumes, err := flattenClusterVolumes(cluster.Volumes)
 	if err != nil {
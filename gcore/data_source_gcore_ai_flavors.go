@@ -0,0 +1,222 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/aiflavors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// aiFlavorGPURegexp parses GPU hardware description of AI flavors, eg. "8x Nvidia H100 80GB"
+var aiFlavorGPURegexp = regexp.MustCompile(`(?i)^\s*(\d+)\s*x\s*(.+?)\s*$`)
+
+func dataSourceAIFlavors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAIFlavorsRead,
+		Description: "Represent list of GPU flavors available for AI clusters in the region",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"include_disabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "set to true to list flavors disabled in the region also",
+				Optional:    true,
+			},
+			"gpu_model": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Matches flavors whose GPU model contains the value, case insensitive, for example 'H100'",
+				Optional:    true,
+			},
+			"min_gpu_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Matches flavors with at least this number of GPUs",
+				Optional:    true,
+			},
+			"flavors": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Flavors matching the filters",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"flavor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavor_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"resource_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"gpu": {
+							Type:        schema.TypeString,
+							Description: "GPU hardware description",
+							Computed:    true,
+						},
+						"gpu_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gpu_model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu": {
+							Type:        schema.TypeString,
+							Description: "CPU hardware description",
+							Computed:    true,
+						},
+						"ram": {
+							Type:        schema.TypeString,
+							Description: "RAM hardware description",
+							Computed:    true,
+						},
+						"disk": {
+							Type:        schema.TypeString,
+							Description: "Disk hardware description",
+							Computed:    true,
+						},
+						"network": {
+							Type:        schema.TypeString,
+							Description: "Network hardware description",
+							Computed:    true,
+						},
+						"capacity": {
+							Type:        schema.TypeInt,
+							Description: "Number of nodes of the flavor that can be created in the region at the moment, -1 if not reported",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "IDs of the flavors, in the same order as 'flavors'",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAIFlavorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start AI flavors reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, AIFlavorsPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := aiflavors.AIFlavorListOpts{
+		Disabled:        d.Get("include_disabled").(bool),
+		IncludeCapacity: true,
+	}
+	flavors, err := aiflavors.ListAll(client, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gpuModel := strings.ToLower(d.Get("gpu_model").(string))
+	minGPUCount := d.Get("min_gpu_count").(int)
+	flavorList := make([]map[string]interface{}, 0, len(flavors))
+	ids := make([]string, 0, len(flavors))
+	for _, flavor := range flavors {
+		var hw aiflavors.HardwareDescription
+		if flavor.HardwareDescription != nil {
+			hw = *flavor.HardwareDescription
+		}
+		gpuCount, model := parseAIFlavorGPU(hw.GPU)
+		if gpuModel != "" && !strings.Contains(strings.ToLower(model), gpuModel) {
+			continue
+		}
+		if gpuCount < minGPUCount {
+			continue
+		}
+
+		capacity := -1
+		if flavor.Capacity != nil {
+			capacity = *flavor.Capacity
+		}
+		flavorList = append(flavorList, map[string]interface{}{
+			"flavor_id":      flavor.FlavorID,
+			"flavor_name":    flavor.FlavorName,
+			"disabled":       flavor.Disabled,
+			"resource_class": flavor.ResourceClass,
+			"gpu":            hw.GPU,
+			"gpu_count":      gpuCount,
+			"gpu_model":      model,
+			"cpu":            hw.CPU,
+			"ram":            hw.RAM,
+			"disk":           hw.Disk,
+			"network":        hw.Network,
+			"capacity":       capacity,
+		})
+		ids = append(ids, flavor.FlavorID)
+	}
+
+	d.SetId(getUniqueID(d))
+	if err := d.Set("flavors", flavorList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish AI flavors reading")
+	return nil
+}
+
+// parseAIFlavorGPU returns number and model of GPUs from the hardware description of the flavor
+func parseAIFlavorGPU(gpu string) (int, string) {
+	match := aiFlavorGPURegexp.FindStringSubmatch(gpu)
+	if match == nil {
+		return 0, strings.TrimSpace(gpu)
+	}
+	count, _ := strconv.Atoi(match[1])
+	return count, match[2]
+}
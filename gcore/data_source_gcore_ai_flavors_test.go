@@ -0,0 +1,26 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import "testing"
+
+func TestParseAIFlavorGPU(t *testing.T) {
+	tests := []struct {
+		gpu       string
+		wantCount int
+		wantModel string
+	}{
+		{"8x Nvidia H100 80GB", 8, "Nvidia H100 80GB"},
+		{"4 x Graphcore Bow-2000", 4, "Graphcore Bow-2000"},
+		{"1X A100", 1, "A100"},
+		{"", 0, ""},
+		{"vGPU", 0, "vGPU"},
+	}
+	for _, tt := range tests {
+		count, model := parseAIFlavorGPU(tt.gpu)
+		if count != tt.wantCount || model != tt.wantModel {
+			t.Errorf("parseAIFlavorGPU(%q) got = %d, %q, want %d, %q", tt.gpu, count, model, tt.wantCount, tt.wantModel)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ai/v1/aiimages"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAIImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAIImagesRead,
+		Description: "Represent list of GPU images available for AI clusters in the region, most recently created first",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Matches images whose name starts with the value",
+				Optional:    true,
+			},
+			"visibility": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Image visibility, for example 'public' or 'private'",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(aiimages.PRIVATE), string(aiimages.PUBLIC), string(aiimages.SHARED)}, false),
+			},
+			"is_baremetal": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "set to true to list images for baremetal GPU flavors, false for virtual ones. Both are listed if not set",
+				Optional:    true,
+			},
+			"metadata_k": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata_kv": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"images": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Images matching the filters, most recently created first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_distro": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"architecture": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"visibility": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_baremetal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"min_disk": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_ram": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "IDs of the images, in the same order as 'images'",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAIImagesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start AI images reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, AIImagesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := aiimages.AIImageListOpts{
+		Visibility: d.Get("visibility").(string),
+		MetadataK:  d.Get("metadata_k").(string),
+	}
+	if metadata, ok := d.GetOk("metadata_kv"); ok {
+		opts.MetadataKV = make(map[string]string)
+		for k, v := range metadata.(map[string]interface{}) {
+			opts.MetadataKV[k] = v.(string)
+		}
+	}
+	allImages, err := aiimages.ListAll(client, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	isBaremetal, checkBaremetal := d.GetOkExists("is_baremetal")
+	collectedImages := make([]aiimages.AIImage, 0, len(allImages))
+	for _, img := range allImages {
+		if !strings.HasPrefix(img.Name, name) {
+			continue
+		}
+		if checkBaremetal && img.IsBaremetal != isBaremetal.(bool) {
+			continue
+		}
+		collectedImages = append(collectedImages, img)
+	}
+	sort.SliceStable(collectedImages, func(i, j int) bool {
+		return collectedImages[i].CreatedAt.After(collectedImages[j].CreatedAt.Time)
+	})
+
+	imageList := make([]map[string]interface{}, 0, len(collectedImages))
+	ids := make([]string, 0, len(collectedImages))
+	for _, img := range collectedImages {
+		imageList = append(imageList, map[string]interface{}{
+			"id":           img.ID,
+			"name":         img.Name,
+			"os_distro":    img.OsDistro,
+			"os_version":   img.OsVersion,
+			"os_type":      img.OsType,
+			"architecture": img.Architecture,
+			"visibility":   img.Visibility,
+			"is_baremetal": img.IsBaremetal,
+			"min_disk":     img.MinDisk,
+			"min_ram":      img.MinRAM,
+			"created_at":   img.CreatedAt.Format(gcorecloud.RFC3339NoZ),
+		})
+		ids = append(ids, img.ID)
+	}
+
+	d.SetId(getUniqueID(d))
+	if err := d.Set("images", imageList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish AI images reading")
+	return nil
+}
@@ -0,0 +1,132 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/G-Core/gcorelabscdn-go/origingroups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCDNOriginGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCDNOriginGroupRead,
+		Description: "Represent origin group found by its ID",
+		Schema: map[string]*schema.Schema{
+			"origin_group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the origin group",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the origin group",
+			},
+			"use_next": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the next origin from the list is used when the origin responds with 4XX or 5XX codes",
+			},
+			"proxy_next_upstream": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Cases in which the request is passed to the next origin",
+			},
+			"auth_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Origin authentication type, 'none' or 'awsSignatureV4'",
+			},
+			"origin": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Origins of the group",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"backup": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"auth": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "S3 storage the group pulls content from. Access keys are not returned by the API.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"s3_storage_hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"s3_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"s3_bucket_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCDNOriginGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CDN OriginGroup reading")
+	config := m.(*Config)
+	client := config.CDNClient
+
+	group, err := client.OriginGroups().Get(ctx, int64(d.Get("origin_group_id").(int)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", group.ID))
+	d.Set("origin_group_id", group.ID)
+	d.Set("name", group.Name)
+	d.Set("use_next", group.UseNext)
+	d.Set("proxy_next_upstream", group.ProxyNextUpstream)
+	d.Set("auth_type", group.AuthType)
+	if err := d.Set("origin", originsToList(group.Sources)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("auth", authToList(group.Auth)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish CDN OriginGroup reading")
+	return nil
+}
+
+func originsToList(origins []origingroups.Source) []interface{} {
+	result := make([]interface{}, 0, len(origins))
+	for _, origin := range origins {
+		result = append(result, map[string]interface{}{
+			"source":  origin.Source,
+			"enabled": origin.Enabled,
+			"backup":  origin.Backup,
+		})
+	}
+
+	return result
+}
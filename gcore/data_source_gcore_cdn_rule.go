@@ -0,0 +1,115 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCDNRule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCDNRuleRead,
+		Description: "Represent cdn resource rule",
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the CDN resource the rule belongs to",
+			},
+			"rule_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the rule",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rule name",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the rule is enabled",
+			},
+			"rule": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A pattern that defines when the rule is triggered",
+			},
+			"rule_type": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Type of rule. Type 0 — RegEx. Type 1 — RegEx, legacy type",
+			},
+			"origin_group": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the Origins Group used by the rule",
+			},
+			"origin_protocol": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The protocol used by CDN servers to request content from an origin source",
+			},
+			"weight": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Rule weight that determines rule execution order",
+			},
+			"options": computedSchema(ruleOptionsSchema),
+		},
+	}
+}
+
+func dataSourceCDNRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CDN Rule reading")
+	config := m.(*Config)
+	client := config.CDNClient
+
+	resourceID := d.Get("resource_id").(int)
+	ruleID := d.Get("rule_id").(int)
+
+	rule, err := client.Rules().Get(ctx, int64(resourceID), int64(ruleID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", rule.ID))
+	d.Set("name", rule.Name)
+	d.Set("active", rule.Active)
+	d.Set("rule", rule.Pattern)
+	d.Set("rule_type", rule.Type)
+	d.Set("origin_group", rule.OriginGroup)
+	d.Set("origin_protocol", rule.OverrideOriginProtocol)
+	d.Set("weight", rule.Weight)
+	if err := d.Set("options", optionsToList(rule.Options)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish CDN Rule reading")
+	return nil
+}
+
+// computedSchema returns a read-only copy of the resource attribute schema to be used in data sources.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+		Sensitive:   s.Sensitive,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		fields := make(map[string]*schema.Schema, len(elem.Schema))
+		for name, field := range elem.Schema {
+			fields[name] = computedSchema(field)
+		}
+		c.Elem = &schema.Resource{Schema: fields}
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	}
+	return c
+}
@@ -0,0 +1,29 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestComputedSchema(t *testing.T) {
+	var check func(path string, s *schema.Schema)
+	check = func(path string, s *schema.Schema) {
+		if !s.Computed || s.Optional || s.Required || s.Default != nil || s.MaxItems != 0 || s.ValidateFunc != nil {
+			t.Errorf("%s is not a read-only attribute: %+v", path, s)
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			for name, field := range elem.Schema {
+				check(path+"."+name, field)
+			}
+		}
+	}
+	check("options", computedSchema(ruleOptionsSchema))
+
+	if err := dataSourceCDNRule().InternalValidate(nil, false); err != nil {
+		t.Errorf("dataSourceCDNRule() schema is invalid: %s", err)
+	}
+}
@@ -0,0 +1,135 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	DNSZoneExportDataSource = "gcore_dns_zone_export"
+
+	DNSZoneExportSchemaZone     = "zone"
+	DNSZoneExportSchemaBIND     = "bind"
+	DNSZoneExportSchemaRRSets   = "rrsets"
+	DNSZoneExportSchemaImportID = "import_id"
+)
+
+func dataSourceDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: checkDNSDependency(dataSourceDNSZoneExportRead),
+		Description: "Export DNS zone records in BIND format together with the list of its RRSets, e.g. to adopt an existing zone with import blocks.",
+		Schema: map[string]*schema.Schema{
+			DNSZoneExportSchemaZone: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A name of DNS Zone to export.",
+			},
+			DNSZoneExportSchemaBIND: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Zone records in BIND zone file format.",
+			},
+			DNSZoneExportSchemaRRSets: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "RRSets of the zone.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						DNSZoneRecordSchemaDomain: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A domain of the RRSet.",
+						},
+						DNSZoneRecordSchemaType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A type of the RRSet.",
+						},
+						DNSZoneRecordSchemaTTL: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "A ttl of the RRSet.",
+						},
+						DNSZoneExportSchemaImportID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID to import the RRSet as gcore_dns_zone_record, in zone:domain:type format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zoneName := strings.TrimSpace(d.Get(DNSZoneExportSchemaZone).(string))
+	log.Printf("[DEBUG] Start DNS Zone Export reading (zone=%s)\n", zoneName)
+	defer log.Println("[DEBUG] Finish DNS Zone Export reading")
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	zone, err := client.Zone(ctx, zoneName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get zone: %w", err))
+	}
+	records := sortedZoneRecords(zone.Records)
+
+	rrsets := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		rrsets = append(rrsets, map[string]interface{}{
+			DNSZoneRecordSchemaDomain:   r.Name,
+			DNSZoneRecordSchemaType:     r.Type,
+			DNSZoneRecordSchemaTTL:      int(r.TTL),
+			DNSZoneExportSchemaImportID: fmt.Sprintf("%s:%s:%s", zone.Name, r.Name, r.Type),
+		})
+	}
+
+	d.SetId(zone.Name)
+	_ = d.Set(DNSZoneExportSchemaZone, zone.Name)
+	_ = d.Set(DNSZoneExportSchemaBIND, zoneRecordsToBIND(zone.Name, records))
+	if err := d.Set(DNSZoneExportSchemaRRSets, rrsets); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// sortedZoneRecords returns copy of records ordered by domain and type to keep export stable
+func sortedZoneRecords(records []dnssdk.ZoneRecord) []dnssdk.ZoneRecord {
+	sorted := make([]dnssdk.ZoneRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	return sorted
+}
+
+func zoneRecordsToBIND(zone string, records []dnssdk.ZoneRecord) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "$ORIGIN %s\n", fqdn(zone))
+	for _, r := range records {
+		for _, answer := range r.ShortAnswers {
+			fmt.Fprintf(&sb, "%s\t%d\tIN\t%s\t%s\n", fqdn(r.Name), r.TTL, strings.ToUpper(r.Type), answer)
+		}
+	}
+	return sb.String()
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
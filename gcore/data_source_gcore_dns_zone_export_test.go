@@ -0,0 +1,27 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+)
+
+func TestZoneRecordsToBIND(t *testing.T) {
+	records := sortedZoneRecords([]dnssdk.ZoneRecord{
+		{Name: "www.example.com", Type: "A", TTL: 300, ShortAnswers: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: "example.com", Type: "MX", TTL: 3600, ShortAnswers: []string{"10 mail.example.com."}},
+		{Name: "example.com", Type: "A", TTL: 3600, ShortAnswers: []string{"192.0.2.10"}},
+	})
+
+	want := "$ORIGIN example.com.\n" +
+		"example.com.\t3600\tIN\tA\t192.0.2.10\n" +
+		"example.com.\t3600\tIN\tMX\t10 mail.example.com.\n" +
+		"www.example.com.\t300\tIN\tA\t192.0.2.1\n" +
+		"www.example.com.\t300\tIN\tA\t192.0.2.2\n"
+	if got := zoneRecordsToBIND("example.com", records); got != want {
+		t.Errorf("zoneRecordsToBIND() got = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	DNSZonesDataSource = "gcore_dns_zones"
+
+	DNSZonesSchemaNames         = "names"
+	DNSZonesSchemaExactMatch    = "exact_match"
+	DNSZonesSchemaCaseSensitive = "case_sensitive"
+	DNSZonesSchemaOffset        = "offset"
+	DNSZonesSchemaLimit         = "limit"
+	DNSZonesSchemaZones         = "zones"
+	DNSZonesSchemaTotalAmount   = "total_amount"
+
+	dnsZonesPageSize = 1000
+)
+
+func dataSourceDNSZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: checkDNSDependency(dataSourceDNSZonesRead),
+		Description: "Represent the list of DNS zones of the account, optionally filtered by name.",
+		Schema: map[string]*schema.Schema{
+			DNSZonesSchemaNames: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Filter zones by names, a zone matches if its name contains any of the values.",
+			},
+			DNSZonesSchemaExactMatch: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Match zone names to the filter exactly instead of by substring.",
+			},
+			DNSZonesSchemaCaseSensitive: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Match zone names to the filter case sensitively.",
+			},
+			DNSZonesSchemaOffset: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of zones to skip.",
+			},
+			DNSZonesSchemaLimit: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of zones to return. All zones are fetched page by page if 0 or omitted.",
+			},
+			DNSZonesSchemaZones: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the zones.",
+			},
+			DNSZonesSchemaTotalAmount: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of zones matching the filter, regardless of offset and limit.",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZonesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start DNS Zones reading")
+	defer log.Println("[DEBUG] Finish DNS Zones reading")
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	rawNames := d.Get(DNSZonesSchemaNames).([]interface{})
+	names := make([]string, 0, len(rawNames))
+	for _, n := range rawNames {
+		names = append(names, strings.TrimSpace(n.(string)))
+	}
+	param := dnssdk.ZonesParam{
+		Offset:        uint64(d.Get(DNSZonesSchemaOffset).(int)),
+		Name:          names,
+		ExactMatch:    d.Get(DNSZonesSchemaExactMatch).(bool),
+		CaseSensitive: d.Get(DNSZonesSchemaCaseSensitive).(bool),
+		OrderBy:       "name",
+	}
+	limit := d.Get(DNSZonesSchemaLimit).(int)
+
+	zones := make([]string, 0)
+	var total int
+	for {
+		param.Limit = dnsZonesPageSize
+		if limit > 0 && limit-len(zones) < dnsZonesPageSize {
+			param.Limit = uint64(limit - len(zones))
+		}
+		res, err := client.ZonesWithParam(ctx, param)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("list zones: %w", err))
+		}
+		if res.Error != "" {
+			return diag.Errorf("list zones: %s", res.Error)
+		}
+		total = res.TotalAmount
+		for _, z := range res.Zones {
+			zones = append(zones, z.Name)
+		}
+		param.Offset += uint64(len(res.Zones))
+		if len(res.Zones) == 0 || param.Offset >= uint64(total) || (limit > 0 && len(zones) >= limit) {
+			break
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(zones, ","))))
+	if err := d.Set(DNSZonesSchemaZones, zones); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set(DNSZonesSchemaTotalAmount, total)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
@@ -59,22 +60,28 @@ func dataSourceImage() *schema.Resource {
 				},
 			},
 			"name": &schema.Schema{
-				Type:         schema.TypeString,
-				Description:  "use 'os-version', for example 'ubuntu-20.04'",
-				Optional:     true,
-				ExactlyOneOf: []string{"name", "image_id"},
+				Type:          schema.TypeString,
+				Description:   "use 'os-version', for example 'ubuntu-20.04'. Matches images whose name starts with the value",
+				Optional:      true,
+				ConflictsWith: []string{"image_id"},
+				AtLeastOneOf:  []string{"name", "image_id", "os_distro"},
 			},
 			"image_id": &schema.Schema{
-				Type:         schema.TypeString,
-				Description:  "use 'image_id' if you know it, for example 'f4b1b1b1-1b1b-1b1b-1b1b-1b1b1b1b1b1b'",
-				Optional:     true,
-				ExactlyOneOf: []string{"name", "image_id"},
+				Type:          schema.TypeString,
+				Description:   "use 'image_id' if you know it, for example 'f4b1b1b1-1b1b-1b1b-1b1b-1b1b1b1b1b1b'",
+				Optional:      true,
+				ConflictsWith: []string{"name"},
 			},
 			"is_baremetal": &schema.Schema{
 				Type:        schema.TypeBool,
 				Description: "set to true if you need to get baremetal image",
 				Optional:    true,
 			},
+			"most_recent": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "If more than one image matches the filters, use the most recently created one instead of failing",
+				Optional:    true,
+			},
 			"min_disk": &schema.Schema{
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -84,10 +91,24 @@ func dataSourceImage() *schema.Resource {
 				Computed: true,
 			},
 			"os_distro": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Description: "OS distribution, for example 'ubuntu'. Filters images when 'image_id' is not set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"os_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "OS version, for example '22.04'. Matches images whose version starts with the value when 'image_id' is not set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"visibility": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Image visibility, for example 'public' or 'private'. Filters images when 'image_id' is not set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"created_at": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
@@ -132,7 +153,6 @@ func dataSourceImage() *schema.Resource {
 
 func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start Image reading")
-	name := d.Get("name").(string)
 	imageID := d.Get("image_id").(string)
 
 	config := m.(*Config)
@@ -154,7 +174,7 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, m interfac
 			return diag.FromErr(err)
 		}
 	} else {
-		image, err = findImageByNameAndMetadata(client, d, name)
+		image, err = findImageByFilters(client, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -167,6 +187,8 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, m interfac
 	d.Set("min_ram", image.MinRAM)
 	d.Set("os_distro", image.OsDistro)
 	d.Set("os_version", image.OsVersion)
+	d.Set("visibility", string(image.Visibility))
+	d.Set("created_at", image.CreatedAt.Format(gcorecloud.RFC3339NoZ))
 	d.Set("description", image.Description)
 
 	metadataReadOnly := make([]map[string]interface{}, 0, len(image.Metadata))
@@ -196,7 +218,69 @@ func imagesNames(images []images.Image) []string {
 
 }
 
-func findImageByNameAndMetadata(client *gcorecloud.ServiceClient, d *schema.ResourceData, name string) (*images.Image, error) {
+type imageFilter struct {
+	Name       string
+	OsDistro   string
+	OsVersion  string
+	Visibility string
+}
+
+func imageFilterFromData(d *schema.ResourceData) imageFilter {
+	return imageFilter{
+		Name:       d.Get("name").(string),
+		OsDistro:   d.Get("os_distro").(string),
+		OsVersion:  d.Get("os_version").(string),
+		Visibility: d.Get("visibility").(string),
+	}
+}
+
+// String lists the filters that are set, for error messages
+func (f imageFilter) String() string {
+	filters := make([]string, 0, 4)
+	for _, filter := range []struct{ key, value string }{
+		{"name", f.Name},
+		{"os_distro", f.OsDistro},
+		{"os_version", f.OsVersion},
+		{"visibility", f.Visibility},
+	} {
+		if filter.value != "" {
+			filters = append(filters, fmt.Sprintf("%s %q", filter.key, filter.value))
+		}
+	}
+	if len(filters) == 0 {
+		return "no filters"
+	}
+	return strings.Join(filters, ", ")
+}
+
+func (f imageFilter) match(img images.Image) bool {
+	hasPrefix := func(s, prefix string) bool {
+		return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+	}
+	equal := func(s, value string) bool {
+		return value == "" || strings.EqualFold(s, value)
+	}
+	return hasPrefix(img.Name, f.Name) &&
+		hasPrefix(img.OsVersion, f.OsVersion) &&
+		equal(img.OsDistro, f.OsDistro) &&
+		equal(string(img.Visibility), f.Visibility)
+}
+
+// filterImages returns images matching the filter, most recently created first
+func filterImages(allImages []images.Image, filter imageFilter) []images.Image {
+	collectedImages := make([]images.Image, 0)
+	for _, img := range allImages {
+		if filter.match(img) {
+			collectedImages = append(collectedImages, img)
+		}
+	}
+	sort.SliceStable(collectedImages, func(i, j int) bool {
+		return collectedImages[i].CreatedAt.After(collectedImages[j].CreatedAt.Time)
+	})
+	return collectedImages
+}
+
+func listImagesByMetadata(client *gcorecloud.ServiceClient, d *schema.ResourceData) ([]images.Image, error) {
 	listOpts := &images.ListOpts{}
 	if metadataK, ok := d.GetOk("metadata_k"); ok {
 		listOpts.MetadataK = metadataK.(string)
@@ -210,26 +294,26 @@ func findImageByNameAndMetadata(client *gcorecloud.ServiceClient, d *schema.Reso
 		listOpts.MetadataKV = typedMetadataKV
 	}
 
-	allImages, err := images.ListAll(client, *listOpts)
+	return images.ListAll(client, *listOpts)
+}
+
+func findImageByFilters(client *gcorecloud.ServiceClient, d *schema.ResourceData) (*images.Image, error) {
+	allImages, err := listImagesByMetadata(client, d)
 	if err != nil {
 		return nil, err
 	}
 
-	collectedImages := make([]images.Image, 0)
-	for _, img := range allImages {
-		if strings.HasPrefix(strings.ToLower(img.Name), strings.ToLower(name)) {
-			collectedImages = append(collectedImages, img)
-		}
-	}
+	filter := imageFilterFromData(d)
+	collectedImages := filterImages(allImages, filter)
 
 	if len(collectedImages) == 0 {
-		return nil, fmt.Errorf("image with name %s not found", name)
+		return nil, fmt.Errorf("image with %s not found", filter)
 	}
 
-	if len(collectedImages) > 1 {
+	if len(collectedImages) > 1 && !d.Get("most_recent").(bool) {
 		return nil, fmt.Errorf(
-			"found more than one image with name %s - %s, pls choose image by iamge_id",
-			name, strings.Join(imagesNames(collectedImages), ", "),
+			"found more than one image with %s - %s, pls choose image by iamge_id or set most_recent",
+			filter, strings.Join(imagesNames(collectedImages), ", "),
 		)
 	}
 
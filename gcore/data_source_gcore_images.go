@@ -0,0 +1,184 @@
+package gcore
+
+import (
+	"context"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceImagesRead,
+		Description: "Represent list of images matching the filters, most recently created first",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"is_baremetal": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "set to true if you need to get baremetal images",
+				Optional:    true,
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Matches images whose name starts with the value",
+				Optional:    true,
+			},
+			"os_distro": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "OS distribution, for example 'ubuntu'",
+				Optional:    true,
+			},
+			"os_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Matches images whose OS version starts with the value, for example '22.04'",
+				Optional:    true,
+			},
+			"visibility": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Image visibility, for example 'public' or 'private'",
+				Optional:    true,
+			},
+			"metadata_k": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata_kv": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"images": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Images matching the filters, most recently created first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_distro": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"visibility": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"min_disk": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_ram": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "IDs of the images, in the same order as 'images'",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceImagesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Images reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	point := imagesPoint
+	if isBm, _ := d.Get("is_baremetal").(bool); isBm {
+		point = bmImagesPoint
+	}
+	client, err := CreateClient(provider, d, point, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allImages, err := listImagesByMetadata(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	collectedImages := filterImages(allImages, imageFilterFromData(d))
+	imageList := make([]map[string]interface{}, 0, len(collectedImages))
+	ids := make([]string, 0, len(collectedImages))
+	for _, img := range collectedImages {
+		imageList = append(imageList, map[string]interface{}{
+			"id":         img.ID,
+			"name":       img.Name,
+			"os_distro":  img.OsDistro,
+			"os_version": img.OsVersion,
+			"visibility": string(img.Visibility),
+			"min_disk":   img.MinDisk,
+			"min_ram":    img.MinRAM,
+			"created_at": img.CreatedAt.Format(gcorecloud.RFC3339NoZ),
+		})
+		ids = append(ids, img.ID)
+	}
+
+	d.SetId(getUniqueID(d))
+	if err := d.Set("images", imageList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish Images reading")
+	return nil
+}
@@ -0,0 +1,111 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type instanceConsoleLog struct {
+	Output string `json:"output"`
+}
+
+func dataSourceInstanceConsoleLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceInstanceConsoleLogRead,
+		Description: "Represent the last lines of the instance console (serial) output, useful for boot debugging.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Description: "Instance ID to fetch console output of",
+				Required:    true,
+			},
+			"lines": {
+				Type:         schema.TypeInt,
+				Description:  "Number of the last console output lines to fetch",
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"output": {
+				Type:        schema.TypeString,
+				Description: "Console output of the instance",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceInstanceConsoleLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance console log reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	lines := d.Get("lines").(int)
+
+	var consoleLog instanceConsoleLog
+	url := fmt.Sprintf("%s?length=%d", client.ServiceURL(instanceID, "console_log"), lines)
+	if _, err := client.Get(url, &consoleLog, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get console log of instance %s: %w", instanceID, err))
+	}
+
+	d.SetId(instanceID)
+	d.Set("output", lastLines(consoleLog.Output, lines))
+
+	log.Println("[DEBUG] Finish Instance console log reading")
+	return nil
+}
+
+// lastLines returns at most n last lines of the text
+func lastLines(text string, n int) string {
+	parts := strings.SplitAfter(strings.TrimSuffix(text, "\n"), "\n")
+	if len(parts) > n {
+		parts = parts[len(parts)-n:]
+	}
+	return strings.Join(parts, "")
+}
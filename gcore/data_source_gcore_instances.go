@@ -0,0 +1,188 @@
+package gcore
+
+import (
+	"context"
+	"log"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceInstances() *schema.Resource {
+	// only attributes of the instance list response are read, so the list doesn't cost a request per instance
+	instanceSchema := instanceV2DataSourceSchema()
+	delete(instanceSchema, "interface")
+	delete(instanceSchema, "image_id")
+	instanceSchema["volume"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"volume_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"delete_on_termination": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+	instanceSchema["region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	instanceSchema["region_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+	instanceSchema["project_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceInstancesRead,
+		Description: "Represent list of instances matching the filters with their addresses, metadata and flavor, eg. to generate dynamic inventory",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Matches instances whose name contains the value",
+				Optional:    true,
+			},
+			"flavor_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata_kv": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "Matches instances having all of the metadata key-value pairs",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Instance status, for example 'ACTIVE'",
+				Optional:    true,
+			},
+			"include_baremetal": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "set to true to get baremetal servers also",
+				Optional:    true,
+			},
+			"instances": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Instances matching the filters",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: instanceSchema,
+				},
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "IDs of the instances, in the same order as 'instances'",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceInstancesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instances reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := instances.ListOpts{
+		Name:             d.Get("name").(string),
+		FlavorID:         d.Get("flavor_id").(string),
+		IncludeBaremetal: d.Get("include_baremetal").(bool),
+	}
+	if metadata, ok := d.GetOk("metadata_kv"); ok {
+		opts.Metadata = make(map[string]string)
+		for k, v := range metadata.(map[string]interface{}) {
+			opts.Metadata[k] = v.(string)
+		}
+	}
+	insts, err := instances.ListAll(client, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status := d.Get("status").(string)
+	instanceList := make([]map[string]interface{}, 0, len(insts))
+	ids := make([]string, 0, len(insts))
+	for _, instance := range insts {
+		if status != "" && instance.Status != status {
+			continue
+		}
+		data := instanceV2DataSourceListData(instance)
+		vols := make([]interface{}, 0, len(instance.Volumes))
+		for _, vol := range instance.Volumes {
+			vols = append(vols, map[string]interface{}{
+				"volume_id":             vol.ID,
+				"delete_on_termination": vol.DeleteOnTermination,
+			})
+		}
+		data["volume"] = vols
+		data["region"] = instance.Region
+		data["region_id"] = instance.RegionID
+		data["project_id"] = instance.ProjectID
+		instanceList = append(instanceList, data)
+		ids = append(ids, instance.ID)
+	}
+
+	d.SetId(getUniqueID(d))
+	if err := d.Set("instances", instanceList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish Instances reading")
+	return nil
+}
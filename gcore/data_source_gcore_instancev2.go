@@ -0,0 +1,471 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceInstanceV2() *schema.Resource {
+	instanceSchema := instanceV2DataSourceSchema()
+	instanceSchema["project_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ExactlyOneOf: []string{
+			"project_id",
+			"project_name",
+		},
+		DiffSuppressFunc: suppressDiffProjectID,
+	}
+	instanceSchema["region_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ExactlyOneOf: []string{
+			"region_id",
+			"region_name",
+		},
+		DiffSuppressFunc: suppressDiffRegionID,
+	}
+	instanceSchema["project_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ExactlyOneOf: []string{
+			"project_id",
+			"project_name",
+		},
+	}
+	instanceSchema["region_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ExactlyOneOf: []string{
+			"region_id",
+			"region_name",
+		},
+	}
+	instanceSchema["instance_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "ID of the instance. Exactly one of instance_id and name must be set.",
+		ExactlyOneOf: []string{
+			"instance_id",
+			"name",
+		},
+	}
+	instanceSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Name of the instance. The lookup fails if several instances have this name.",
+		ExactlyOneOf: []string{
+			"instance_id",
+			"name",
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceInstanceV2Read,
+		Description: "Represent instance with details of its interfaces, volumes, metadata and flavor. Could be used with baremetal also",
+		Schema:      instanceSchema,
+	}
+}
+
+// instanceV2DataSourceSchema returns computed attributes describing an instance
+func instanceV2DataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"instance_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Instance description kept in the 'description' metadata item, the item is not part of metadata_map",
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"vm_state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"availability_zone": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"image_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Image of the boot volume",
+		},
+		"flavor_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"flavor": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "Flavor details: flavor_id, flavor_name, ram, vcpus, os_type and architecture",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"metadata_map": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"volume": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"volume_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"size": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"type_name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"image_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"bootable": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"device": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"delete_on_termination": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"interface": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"port_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"network_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"subnet_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ip_address": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ipv6_address": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"mac_address": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"port_security_enabled": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"fip_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"fip_address": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"security_groups": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"addresses": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"net": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"addr": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"type": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"primary_ipv4": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"primary_ipv6": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clientVol, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var instance *instances.Instance
+	if instanceID := d.Get("instance_id").(string); instanceID != "" {
+		instance, err = instances.Get(client, instanceID).Extract()
+		if err != nil {
+			switch err.(type) {
+			case gcorecloud.ErrDefault404:
+				return diag.Errorf("instance with id %s not found", instanceID)
+			default:
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		name := d.Get("name").(string)
+		insts, err := instances.ListAll(client, instances.ListOpts{Name: name})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, inst := range insts {
+			if inst.Name != name {
+				continue
+			}
+			if instance != nil {
+				return diag.Errorf("several instances with name %s found, use instance_id instead", name)
+			}
+			inst := inst
+			instance = &inst
+		}
+		if instance == nil {
+			return diag.Errorf("instance with name %s not found", name)
+		}
+	}
+
+	data, err := instanceV2DataSourceData(client, clientVol, *instance)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(instance.ID)
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish Instance reading")
+	return diags
+}
+
+// instanceV2DataSourceData collects attributes of instanceV2DataSourceSchema for the instance
+func instanceV2DataSourceData(client, clientVol *gcorecloud.ServiceClient, instance instances.Instance) (map[string]interface{}, error) {
+	data := instanceV2DataSourceListData(instance)
+
+	var bootImageID string
+	vols := make([]interface{}, 0, len(instance.Volumes))
+	for _, vol := range instance.Volumes {
+		volume, err := volumes.Get(clientVol, vol.ID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get volume %s: %w", vol.ID, err)
+		}
+		v := map[string]interface{}{
+			"volume_id":             vol.ID,
+			"name":                  volume.Name,
+			"size":                  volume.Size,
+			"type_name":             volume.VolumeType.String(),
+			"image_id":              volume.VolumeImageMetadata.ImageID,
+			"bootable":              volume.Bootable,
+			"device":                "",
+			"delete_on_termination": vol.DeleteOnTermination,
+		}
+		for _, attachment := range volume.Attachments {
+			if attachment.ServerID == instance.ID {
+				v["device"] = attachment.Device
+			}
+		}
+		if volume.Bootable && bootImageID == "" {
+			bootImageID = volume.VolumeImageMetadata.ImageID
+		}
+		vols = append(vols, v)
+	}
+	data["volume"] = vols
+	data["image_id"] = bootImageID
+
+	ports, err := instances.ListPortsAll(client, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list ports of instance %s: %w", instance.ID, err)
+	}
+	ifs, err := instances.ListInterfacesAll(client, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list interfaces of instance %s: %w", instance.ID, err)
+	}
+	data["interface"] = instanceV2DataSourceInterfaces(ifs, ports)
+
+	return data, nil
+}
+
+// instanceV2DataSourceListData collects attributes of the instance available in the instance list response
+func instanceV2DataSourceListData(instance instances.Instance) map[string]interface{} {
+	data := map[string]interface{}{
+		"instance_id":       instance.ID,
+		"name":              instance.Name,
+		"status":            instance.Status,
+		"vm_state":          instance.VMState,
+		"availability_zone": instance.AvailabilityZone,
+		"flavor_id":         instance.Flavor.FlavorID,
+		"flavor": map[string]interface{}{
+			"flavor_id":    instance.Flavor.FlavorID,
+			"flavor_name":  instance.Flavor.FlavorName,
+			"ram":          strconv.Itoa(instance.Flavor.RAM),
+			"vcpus":        strconv.Itoa(instance.Flavor.VCPUS),
+			"os_type":      instance.Flavor.OsType,
+			"architecture": instance.Flavor.Architecture,
+		},
+	}
+
+	// the description is kept in the metadata item managed by the description attribute of gcore_instancev2
+	data["description"] = ""
+	metadata := make(map[string]interface{}, len(instance.Metadata))
+	for k, v := range instance.Metadata {
+		if k == instanceDescriptionMetadataKey {
+			data["description"] = fmt.Sprint(v)
+			continue
+		}
+		metadata[k] = fmt.Sprint(v)
+	}
+	data["metadata_map"] = metadata
+
+	addresses := make([]interface{}, 0, len(instance.Addresses))
+	for _, addrs := range instance.Addresses {
+		net := make([]interface{}, len(addrs))
+		for i, iaddr := range addrs {
+			net[i] = map[string]interface{}{
+				"type": iaddr.Type.String(),
+				"addr": iaddr.Address.String(),
+			}
+		}
+		addresses = append(addresses, map[string]interface{}{"net": net})
+	}
+	data["addresses"] = addresses
+	data["primary_ipv4"], data["primary_ipv6"] = instanceV2PrimaryAddresses(instance.Addresses)
+
+	return data
+}
+
+// instanceV2DataSourceInterfaces returns every interface of the instance with its floating IP and security groups
+func instanceV2DataSourceInterfaces(ifs []instances.Interface, ports []instances.InstancePorts) []interface{} {
+	result := make([]interface{}, 0, len(ifs))
+	for _, iface := range ifs {
+		i := map[string]interface{}{
+			"port_id":               iface.PortID,
+			"network_id":            iface.NetworkID,
+			"mac_address":           iface.MacAddress.String(),
+			"port_security_enabled": iface.PortSecurityEnabled,
+			"name":                  "",
+			"subnet_id":             "",
+			"ip_address":            "",
+			"ipv6_address":          "",
+			"fip_id":                "",
+			"fip_address":           "",
+		}
+		if iface.Name != nil {
+			i["name"] = *iface.Name
+		}
+		if len(iface.IPAssignments) > 0 {
+			assignments, ipv6Address := instanceV2InterfaceAssignments(iface.IPAssignments)
+			i["subnet_id"] = assignments[0].SubnetID
+			i["ip_address"] = assignments[0].IPAddress.String()
+			i["ipv6_address"] = ipv6Address
+		}
+		if len(iface.FloatingIPDetails) > 0 {
+			i["fip_id"] = iface.FloatingIPDetails[0].ID
+			i["fip_address"] = iface.FloatingIPDetails[0].FloatingIPAddress.String()
+		}
+
+		sgs := make([]interface{}, 0)
+		if port, err := findInstancePort(iface.PortID, ports); err == nil {
+			for _, sg := range port.SecurityGroups {
+				sgs = append(sgs, map[string]interface{}{"id": sg.ID, "name": sg.Name})
+			}
+		}
+		i["security_groups"] = sgs
+
+		result = append(result, i)
+	}
+	return result
+}
@@ -0,0 +1,26 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+)
+
+func TestInstanceV2DataSourceListDataDescription(t *testing.T) {
+	instance := instances.Instance{
+		ID:       "instance-id",
+		Metadata: map[string]interface{}{instanceDescriptionMetadataKey: "owned by team-a", "env": "prod"},
+	}
+
+	data := instanceV2DataSourceListData(instance)
+	if got := data["description"]; got != "owned by team-a" {
+		t.Errorf("description = %v, want %q", got, "owned by team-a")
+	}
+	if want := map[string]interface{}{"env": "prod"}; !reflect.DeepEqual(data["metadata_map"], want) {
+		t.Errorf("metadata_map = %v, want %v", data["metadata_map"], want)
+	}
+}
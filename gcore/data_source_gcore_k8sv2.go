@@ -310,6 +310,27 @@ func dataSourceK8sV2() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"max_pods_per_node": {
+							Type:        schema.TypeInt,
+							Description: "Maximum number of pods per pool node, parsed from maxPods of kubelet_config.",
+							Computed:    true,
+						},
+						"kube_reserved": {
+							Type:        schema.TypeMap,
+							Description: "Resources reserved for kubernetes system daemons on pool nodes, parsed from kubeReserved of kubelet_config.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"system_reserved": {
+							Type:        schema.TypeMap,
+							Description: "Resources reserved for OS system daemons on pool nodes, parsed from systemReserved of kubelet_config.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 						"servergroup_policy": {
 							Type:        schema.TypeString,
 							Description: "Server group policy: anti-affinity, soft-anti-affinity or affinity",
@@ -458,7 +479,7 @@ func dataSourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interfac
 
 	var ps []map[string]interface{}
 	for _, pool := range cluster.Pools {
-		ps = append(ps, map[string]interface{}{
+		data := map[string]interface{}{
 			"name":                 pool.Name,
 			"flavor_id":            pool.FlavorID,
 			"min_node_count":       pool.MinNodeCount,
@@ -471,13 +492,16 @@ func dataSourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interfac
 			"labels":               resourceK8sV2FilteredPoolLabels(pool.Labels),
 			"taints":               pool.Taints,
 			"crio_config":          pool.CrioConfig,
-			"kubelet_config":       pool.KubeletConfig,
 			"servergroup_policy":   pool.ServerGroupPolicy,
 			"servergroup_name":     pool.ServerGroupName,
 			"servergroup_id":       pool.ServerGroupID,
 			"status":               pool.Status,
 			"created_at":           pool.CreatedAt.Format(time.RFC850),
-		})
+		}
+		resourceK8sV2PoolKubeletData(nil, data, pool.KubeletConfig)
+		// keys parsed into max_pods_per_node, kube_reserved and system_reserved stay in the raw config
+		data["kubelet_config"] = pool.KubeletConfig
+		ps = append(ps, data)
 	}
 	if err := d.Set("pools", ps); err != nil {
 		return diag.FromErr(err)
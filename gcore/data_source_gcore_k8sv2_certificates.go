@@ -0,0 +1,168 @@
+package gcore
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/clusters"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	K8sCertificateValid    = "valid"
+	K8sCertificateExpiring = "expiring"
+	K8sCertificateExpired  = "expired"
+)
+
+func dataSourceK8sV2Certificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceK8sV2CertificatesRead,
+		Description: "Represent k8s cluster's CA certificate and its expiration.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Description: "Cluster name to fetch certificates",
+				Required:    true,
+			},
+			"expiry_threshold_days": {
+				Type:         schema.TypeInt,
+				Description:  "Number of days before the CA certificate expiration the rotation_status becomes 'expiring'",
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Description: "Cluster CA certificate in PEM format",
+				Computed:    true,
+			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Description: "Date (RFC3339) the CA certificate is valid from",
+				Computed:    true,
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Description: "Date (RFC3339) the CA certificate expires",
+				Computed:    true,
+			},
+			"expires_in_days": {
+				Type:        schema.TypeInt,
+				Description: "Number of whole days left until the CA certificate expires",
+				Computed:    true,
+			},
+			"last_rotated_at": {
+				Type:        schema.TypeString,
+				Description: "Date (RFC3339) the current CA certificate was issued, ie. the cluster creation or the last certificates rotation",
+				Computed:    true,
+			},
+			"rotation_status": {
+				Type:        schema.TypeString,
+				Description: "Whether the CA certificate needs rotation. Possible values: 'valid', 'expiring' (expires within expiry_threshold_days), 'expired'",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceK8sV2CertificatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start K8s certificates reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, K8sPoint, versionPointV2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterName := d.Get("cluster_name").(string)
+	cluster, err := clusters.Get(client, clusterName).Extract()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cant get cluster: %s", err.Error()))
+	}
+
+	certificate, err := clusters.GetCertificate(client, clusterName).Extract()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cant get cluster certificate: %s", err.Error()))
+	}
+
+	ca, err := parseK8sCertificate(certificate.Certificate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(cluster.Name)
+	d.Set("ca_certificate", certificate.Certificate)
+	d.Set("not_before", ca.NotBefore.Format(time.RFC3339))
+	d.Set("not_after", ca.NotAfter.Format(time.RFC3339))
+	d.Set("expires_in_days", int(time.Until(ca.NotAfter).Hours()/24))
+	d.Set("last_rotated_at", ca.NotBefore.Format(time.RFC3339))
+	d.Set("rotation_status", k8sCertificateRotationStatus(ca, time.Now(), d.Get("expiry_threshold_days").(int)))
+
+	log.Println("[DEBUG] Finish K8s certificates reading")
+	return diags
+}
+
+func parseK8sCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("cant decode cluster certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cant parse cluster certificate: %w", err)
+	}
+	return cert, nil
+}
+
+func k8sCertificateRotationStatus(cert *x509.Certificate, now time.Time, thresholdDays int) string {
+	switch {
+	case !now.Before(cert.NotAfter):
+		return K8sCertificateExpired
+	case now.AddDate(0, 0, thresholdDays).After(cert.NotAfter):
+		return K8sCertificateExpiring
+	default:
+		return K8sCertificateValid
+	}
+}
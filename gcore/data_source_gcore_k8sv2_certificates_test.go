@@ -0,0 +1,29 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestK8sCertificateRotationStatus(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		notAfter time.Time
+		want     string
+	}{
+		{notAfter: now.AddDate(0, 2, 0), want: K8sCertificateValid},
+		{notAfter: now.AddDate(0, 0, 10), want: K8sCertificateExpiring},
+		{notAfter: now, want: K8sCertificateExpired},
+		{notAfter: now.AddDate(0, 0, -1), want: K8sCertificateExpired},
+	}
+	for _, tc := range tests {
+		cert := &x509.Certificate{NotAfter: tc.notAfter}
+		if got := k8sCertificateRotationStatus(cert, now, 30); got != tc.want {
+			t.Errorf("k8sCertificateRotationStatus(%s) = %q, want %q", tc.notAfter, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type k8sV2Task struct {
+	ID               string                 `json:"id"`
+	TaskType         string                 `json:"task_type"`
+	State            string                 `json:"state"`
+	Error            *string                `json:"error"`
+	CreatedOn        string                 `json:"created_on"`
+	FinishedOn       *string                `json:"finished_on"`
+	Data             map[string]interface{} `json:"data"`
+	CreatedResources map[string]interface{} `json:"created_resources"`
+}
+
+// k8sV2TasksPageLimit is the number of tasks requested per page
+const k8sV2TasksPageLimit = 100
+
+type k8sV2TaskList struct {
+	Count   int         `json:"count"`
+	Results []k8sV2Task `json:"results"`
+}
+
+func dataSourceK8sV2Events() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceK8sV2EventsRead,
+		Description: "Represent recent lifecycle events (tasks) of k8s cluster and its pools, newest first. Useful to investigate failed applies without portal access.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Description: "Cluster name to fetch events",
+				Required:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of events to return",
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Description: "Cluster events, newest first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"task_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the task",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Task type, eg. create_k8s_cluster_v2 or delete_k8s_cluster_pool_v2",
+							Computed:    true,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Description: "Task state: NEW, RUNNING, FINISHED or ERROR",
+							Computed:    true,
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Description: "Error message of the failed task",
+							Computed:    true,
+						},
+						"timestamp": {
+							Type:        schema.TypeString,
+							Description: "Date the task was created",
+							Computed:    true,
+						},
+						"finished_at": {
+							Type:        schema.TypeString,
+							Description: "Date the task was finished",
+							Computed:    true,
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Description: "Name of the pool the task is related to, empty for cluster wide tasks",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceK8sV2EventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start K8s events reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, err := GetProject(provider, d.Get("project_id").(int), d.Get("project_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	regionID, err := GetRegion(provider, d.Get("region_id").(int), d.Get("region_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// tasks are listed account wide and filtered by project and region
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    tasksPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV1,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	baseURL := fmt.Sprintf("%s?project_id=%d&region_id=%d&orderby=created_on&sorting=desc&limit=%d",
+		strings.TrimSuffix(client.ResourceBaseURL(), "/"), projectID, regionID, k8sV2TasksPageLimit)
+	fetch := func(offset int) (*k8sV2TaskList, error) {
+		var taskList k8sV2TaskList
+		if _, err := client.Get(fmt.Sprintf("%s&offset=%d", baseURL, offset), &taskList, nil); err != nil {
+			return nil, err
+		}
+		return &taskList, nil
+	}
+
+	clusterName := d.Get("cluster_name").(string)
+	limit := d.Get("limit").(int)
+	taskList, err := collectK8sV2ClusterTasks(fetch, clusterName, limit)
+	if err != nil {
+		return diag.Errorf("cant get tasks: %s", err)
+	}
+	events := k8sV2ClusterEvents(taskList, clusterName, limit)
+
+	d.SetId(fmt.Sprintf("%d:%d:%s", projectID, regionID, clusterName))
+	if err := d.Set("events", events); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish K8s events reading")
+	return diags
+}
+
+// collectK8sV2ClusterTasks pages through the tasks, newest first, until limit tasks of the cluster are found
+func collectK8sV2ClusterTasks(fetch func(offset int) (*k8sV2TaskList, error), clusterName string, limit int) ([]k8sV2Task, error) {
+	var related []k8sV2Task
+	offset := 0
+	for {
+		taskList, err := fetch(offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range taskList.Results {
+			if isK8sV2ClusterTask(task, clusterName) {
+				related = append(related, task)
+			}
+		}
+		offset += len(taskList.Results)
+		if len(related) >= limit || len(taskList.Results) == 0 || offset >= taskList.Count {
+			return related, nil
+		}
+	}
+}
+
+func isK8sV2ClusterTask(task k8sV2Task, clusterName string) bool {
+	return strings.Contains(task.TaskType, "k8s") && k8sV2TaskCluster(task) == clusterName
+}
+
+// k8sV2ClusterEvents returns events of k8s tasks related to the cluster, newest first
+func k8sV2ClusterEvents(taskList []k8sV2Task, clusterName string, limit int) []map[string]interface{} {
+	related := make([]k8sV2Task, 0, len(taskList))
+	for _, task := range taskList {
+		if isK8sV2ClusterTask(task, clusterName) {
+			related = append(related, task)
+		}
+	}
+	sort.SliceStable(related, func(i, j int) bool { return related[i].CreatedOn > related[j].CreatedOn })
+	if len(related) > limit {
+		related = related[:limit]
+	}
+
+	events := make([]map[string]interface{}, 0, len(related))
+	for _, task := range related {
+		event := map[string]interface{}{
+			"task_id":     task.ID,
+			"type":        task.TaskType,
+			"state":       task.State,
+			"message":     "",
+			"timestamp":   task.CreatedOn,
+			"finished_at": "",
+			"pool":        k8sV2TaskPool(task),
+		}
+		if task.Error != nil {
+			event["message"] = *task.Error
+		}
+		if task.FinishedOn != nil {
+			event["finished_at"] = *task.FinishedOn
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// k8sV2TaskCluster returns name of the cluster the task was created for
+func k8sV2TaskCluster(task k8sV2Task) string {
+	for _, key := range []string{"cluster_name", "name"} {
+		if name, ok := task.Data[key].(string); ok && name != "" {
+			return name
+		}
+	}
+	if clusters, ok := task.CreatedResources["k8s_clusters"].([]interface{}); ok && len(clusters) > 0 {
+		if name, ok := clusters[0].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// k8sV2TaskPool returns name of the pool the task was created for
+func k8sV2TaskPool(task k8sV2Task) string {
+	if name, ok := task.Data["pool_name"].(string); ok {
+		return name
+	}
+	if pool, ok := task.Data["pool"].(map[string]interface{}); ok {
+		if name, ok := pool["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
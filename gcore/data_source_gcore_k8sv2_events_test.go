@@ -0,0 +1,80 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+)
+
+func TestK8sV2ClusterEvents(t *testing.T) {
+	failure := "not enough quota"
+	finished := "2024-05-01T10:20:00+0000"
+	taskList := []k8sV2Task{
+		{ID: "1", TaskType: "create_k8s_cluster_v2", State: "FINISHED", CreatedOn: "2024-05-01T10:00:00+0000", FinishedOn: &finished, Data: map[string]interface{}{"name": "prod"}},
+		{ID: "2", TaskType: "create_k8s_cluster_pool_v2", State: "ERROR", Error: &failure, CreatedOn: "2024-05-02T10:00:00+0000", Data: map[string]interface{}{"cluster_name": "prod", "pool": map[string]interface{}{"name": "gpu"}}},
+		{ID: "3", TaskType: "create_k8s_cluster_v2", State: "FINISHED", CreatedOn: "2024-05-03T10:00:00+0000", Data: map[string]interface{}{"name": "dev"}},
+		{ID: "4", TaskType: "create_vm", State: "FINISHED", CreatedOn: "2024-05-04T10:00:00+0000", Data: map[string]interface{}{"name": "prod"}},
+		{ID: "5", TaskType: "delete_k8s_cluster_pool_v2", State: "RUNNING", CreatedOn: "2024-05-05T10:00:00+0000", Data: map[string]interface{}{"cluster_name": "prod", "pool_name": "cpu"}},
+	}
+
+	events := k8sV2ClusterEvents(taskList, "prod", 20)
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e["task_id"].(string))
+	}
+	if len(ids) != 3 || ids[0] != "5" || ids[1] != "2" || ids[2] != "1" {
+		t.Fatalf("task ids = %v, want [5 2 1]", ids)
+	}
+	if events[0]["pool"] != "cpu" || events[1]["pool"] != "gpu" || events[2]["pool"] != "" {
+		t.Errorf("pools = %v, %v, %v", events[0]["pool"], events[1]["pool"], events[2]["pool"])
+	}
+	if events[1]["message"] != failure {
+		t.Errorf("message = %v, want %s", events[1]["message"], failure)
+	}
+	if events[2]["finished_at"] != finished {
+		t.Errorf("finished_at = %v, want %s", events[2]["finished_at"], finished)
+	}
+
+	if events := k8sV2ClusterEvents(taskList, "prod", 1); len(events) != 1 || events[0]["task_id"] != "5" {
+		t.Errorf("limited events = %v", events)
+	}
+}
+
+func TestCollectK8sV2ClusterTasks(t *testing.T) {
+	pages := map[int]*k8sV2TaskList{
+		0: {Count: 5, Results: []k8sV2Task{
+			{ID: "5", TaskType: "delete_k8s_cluster_pool_v2", Data: map[string]interface{}{"cluster_name": "prod"}},
+			{ID: "4", TaskType: "create_vm", Data: map[string]interface{}{"name": "prod"}},
+		}},
+		2: {Count: 5, Results: []k8sV2Task{
+			{ID: "3", TaskType: "create_k8s_cluster_v2", Data: map[string]interface{}{"name": "dev"}},
+			{ID: "2", TaskType: "create_k8s_cluster_pool_v2", Data: map[string]interface{}{"cluster_name": "prod"}},
+		}},
+		4: {Count: 5, Results: []k8sV2Task{
+			{ID: "1", TaskType: "create_k8s_cluster_v2", Data: map[string]interface{}{"name": "prod"}},
+		}},
+	}
+	var offsets []int
+	fetch := func(offset int) (*k8sV2TaskList, error) {
+		offsets = append(offsets, offset)
+		return pages[offset], nil
+	}
+
+	tasks, err := collectK8sV2ClusterTasks(fetch, "prod", 20)
+	if err != nil {
+		t.Fatalf("collectK8sV2ClusterTasks() error = %v", err)
+	}
+	if len(tasks) != 3 || len(offsets) != 3 {
+		t.Errorf("collected %d tasks from offsets %v, want 3 tasks from [0 2 4]", len(tasks), offsets)
+	}
+
+	offsets = nil
+	tasks, err = collectK8sV2ClusterTasks(fetch, "prod", 2)
+	if err != nil {
+		t.Fatalf("collectK8sV2ClusterTasks() error = %v", err)
+	}
+	if len(tasks) != 2 || len(offsets) != 2 {
+		t.Errorf("collected %d tasks from offsets %v, want 2 tasks from [0 2]", len(tasks), offsets)
+	}
+}
@@ -58,6 +58,34 @@ func dataSourceK8sV2KubeConfig() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Raw kubeconfig file",
 				Computed:    true,
+				Sensitive:   true,
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "Kubernetes API server URL",
+				Computed:    true,
+			},
+			"certificate_authority_data": {
+				Type:        schema.TypeString,
+				Description: "Base64 encoded CA certificate of the Kubernetes API server",
+				Computed:    true,
+			},
+			"client_certificate_data": {
+				Type:        schema.TypeString,
+				Description: "Base64 encoded client certificate of the kubeconfig user",
+				Computed:    true,
+			},
+			"client_key_data": {
+				Type:        schema.TypeString,
+				Description: "Base64 encoded client key of the kubeconfig user",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "Bearer token of the kubeconfig user",
+				Computed:    true,
+				Sensitive:   true,
 			},
 		},
 	}
@@ -81,10 +109,24 @@ func dataSourceK8sV2KubeConfigRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	kubeconfig, err := clusters.GetConfig(client, clusterName).Extract()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cant get cluster kubeconfig: %s", err.Error()))
+	}
 
 	d.SetId(cluster.Name)
 	d.Set("kubeconfig", kubeconfig.Config)
 
+	cfg, err := parseK8sKubeconfig(kubeconfig.Config)
+	if err != nil {
+		log.Printf("[WARN] Cannot parse kubeconfig of cluster %s: %s", clusterName, err)
+	} else {
+		d.Set("host", cfg.Server)
+		d.Set("certificate_authority_data", cfg.CertificateAuthorityData)
+		d.Set("client_certificate_data", cfg.ClientCertificateData)
+		d.Set("client_key_data", cfg.ClientKeyData)
+		d.Set("token", cfg.Token)
+	}
+
 	log.Println("[DEBUG] Finish K8s kubeconfig reading")
 	return diags
 }
@@ -128,7 +128,7 @@ func dataSourceLBPool() *schema.Resource {
 						},
 						"expected_codes": &schema.Schema{
 							Type:        schema.TypeString,
-							Description: "The list of HTTP status codes expected in response from the member to declare it healthy.",
+							Description: "The HTTP status codes expected in response from the member to declare it healthy.",
 							Computed:    true,
 						},
 					},
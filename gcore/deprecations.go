@@ -0,0 +1,102 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// applyStrictDeprecations makes every deprecated resource, data source and top level attribute fail the plan
+// when the provider is configured with strict_deprecations. Terraform reports them as warnings otherwise,
+// using the same Deprecated/DeprecationMessage texts as replacement hints.
+func applyStrictDeprecations(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		attrs := deprecatedAttributes(r.Schema)
+		if r.DeprecationMessage == "" && len(attrs) == 0 {
+			continue
+		}
+		check := deprecationCustomizeDiff(name, r.DeprecationMessage, attrs)
+		if r.CustomizeDiff == nil {
+			r.CustomizeDiff = check
+			continue
+		}
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if err := check(ctx, diff, meta); err != nil {
+				return err
+			}
+			return customizeDiff(ctx, diff, meta)
+		}
+	}
+
+	for name, r := range p.DataSourcesMap {
+		if r.DeprecationMessage == "" || r.ReadContext == nil {
+			continue
+		}
+		name, message, read := name, r.DeprecationMessage, r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if strictDeprecationsEnabled(meta) {
+				return diag.Errorf("%s", deprecationError(name, message, nil))
+			}
+			return read(ctx, d, meta)
+		}
+	}
+}
+
+// deprecatedAttributes returns top level attributes with a deprecation hint, keyed by name
+func deprecatedAttributes(s map[string]*schema.Schema) map[string]string {
+	attrs := make(map[string]string)
+	for k, v := range s {
+		if v.Deprecated != "" {
+			attrs[k] = v.Deprecated
+		}
+	}
+	return attrs
+}
+
+func deprecationCustomizeDiff(name, message string, attrs map[string]string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if !strictDeprecationsEnabled(meta) {
+			return nil
+		}
+
+		used := make(map[string]string)
+		config := diff.GetRawConfig()
+		if !config.IsNull() && config.Type().IsObjectType() {
+			for attr, hint := range attrs {
+				if config.Type().HasAttribute(attr) && !config.GetAttr(attr).IsNull() {
+					used[attr] = hint
+				}
+			}
+		}
+		if message == "" && len(used) == 0 {
+			return nil
+		}
+		return deprecationError(name, message, used)
+	}
+}
+
+func deprecationError(name, message string, attrs map[string]string) error {
+	var problems []string
+	if message != "" {
+		problems = append(problems, fmt.Sprintf("%s is deprecated: %s", name, message))
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		problems = append(problems, fmt.Sprintf("%s.%s is deprecated: %s", name, k, attrs[k]))
+	}
+	return fmt.Errorf("%s (%s is enabled)", strings.Join(problems, "; "), ProviderOptStrictDeprecations)
+}
+
+func strictDeprecationsEnabled(meta interface{}) bool {
+	config, ok := meta.(*Config)
+	return ok && config.StrictDeprecations
+}
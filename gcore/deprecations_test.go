@@ -0,0 +1,35 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDeprecationError(t *testing.T) {
+	err := deprecationError("gcore_instance", "Use gcore_instancev2 instead", map[string]string{
+		"userdata":       "Use user_data instead",
+		"name_templates": "Use name_template instead",
+	})
+	want := "gcore_instance is deprecated: Use gcore_instancev2 instead; " +
+		"gcore_instance.name_templates is deprecated: Use name_template instead; " +
+		"gcore_instance.userdata is deprecated: Use user_data instead (strict_deprecations is enabled)"
+	if err.Error() != want {
+		t.Errorf("deprecationError() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestApplyStrictDeprecations(t *testing.T) {
+	p := Provider()
+	if p.ResourcesMap["gcore_instance"].CustomizeDiff == nil {
+		t.Error("deprecated resource gcore_instance has no deprecation check")
+	}
+
+	read := p.DataSourcesMap["gcore_loadbalancer"].ReadContext
+	d := p.DataSourcesMap["gcore_loadbalancer"].TestResourceData()
+	if diags := read(context.Background(), d, &Config{StrictDeprecations: true}); !diags.HasError() {
+		t.Error("deprecated data source gcore_loadbalancer is readable with strict_deprecations")
+	}
+}
@@ -0,0 +1,342 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	instanceV2SecurityGroupsModeReplace = "replace"
+	instanceV2SecurityGroupsModeAppend  = "append"
+)
+
+// instanceV2InterfaceIdentity lists per interface type the attributes that identify the attached port,
+// changing any of them requires the interface to be detached and attached again
+var instanceV2InterfaceIdentity = map[types.InterfaceType][]string{
+	types.SubnetInterfaceType:    {"subnet_id"},
+	types.AnySubnetInterfaceType: {"network_id"},
+	types.ReservedFixedIpType:    {"port_id"},
+	types.ExternalInterfaceType:  {},
+}
+
+// instanceInterfaceTypes lists interface types accepted by gcore_instancev2 and gcore_instance_interface
+var instanceInterfaceTypes = []string{
+	types.SubnetInterfaceType.String(),
+	types.AnySubnetInterfaceType.String(),
+	types.ExternalInterfaceType.String(),
+	types.ReservedFixedIpType.String(),
+}
+
+// validateInstanceV2Interfaces checks that interfaces can be matched by name with the interfaces of the instance
+func validateInstanceV2Interfaces(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	names := make(map[string]bool)
+	for _, raw := range diff.Get("interface").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		name := raw.(map[string]interface{})["name"].(string)
+		if names[name] {
+			return fmt.Errorf("interface name %q is used more than once, interfaces are matched by name", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// instanceV2InterfacesByName indexes interfaces of the resource by name
+func instanceV2InterfacesByName(ifs []interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, len(ifs))
+	for _, raw := range ifs {
+		if raw == nil {
+			continue
+		}
+		iface := raw.(map[string]interface{})
+		result[iface["name"].(string)] = iface
+	}
+	return result
+}
+
+// instanceV2InterfaceReplaced returns true if the interface kept its name but has to be attached again.
+// Attributes unknown in the old interface, eg. after import, are not treated as changed.
+func instanceV2InterfaceReplaced(old, new map[string]interface{}) bool {
+	oldType, _ := old["type"].(string)
+	newType, _ := new["type"].(string)
+	if oldType == "" {
+		return false
+	}
+	if oldType != newType {
+		return true
+	}
+	for _, key := range instanceV2InterfaceIdentity[types.InterfaceType(newType)] {
+		oldValue, _ := old[key].(string)
+		newValue, _ := new[key].(string)
+		if oldValue != "" && newValue != "" && oldValue != newValue {
+			return true
+		}
+	}
+	return instanceInterfaceIPFamily(old) != instanceInterfaceIPFamily(new)
+}
+
+// instanceV2InterfaceAssignment picks the assignment in the subnet kept in state, falling back to the first one
+func instanceV2InterfaceAssignment(assignments []instances.PortIP, subnetID string) instances.PortIP {
+	for _, assignment := range assignments {
+		if assignment.SubnetID == subnetID {
+			return assignment
+		}
+	}
+	return assignments[0]
+}
+
+// instanceV2OrderInterfaces returns interfaces read from the API in the order they have in state,
+// interfaces unknown to the state follow in the order of the API.
+func instanceV2OrderInterfaces(state []interface{}, names []string, read map[string]map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(names))
+	added := make(map[string]bool, len(names))
+	for _, raw := range state {
+		if raw == nil {
+			continue
+		}
+		name := raw.(map[string]interface{})["name"].(string)
+		if iface, ok := read[name]; ok && !added[name] {
+			result = append(result, iface)
+			added[name] = true
+		}
+	}
+	for _, name := range names {
+		if !added[name] {
+			result = append(result, read[name])
+			added[name] = true
+		}
+	}
+	return result
+}
+
+// instanceV2SecurityGroupsChange returns security groups to be unassigned from and assigned to the port. In replace mode
+// the port keeps only configured groups, in append mode groups assigned outside of terraform are left untouched and
+// only groups removed from the configuration are unassigned. The ignored group, eg. the one for the application ports,
+// is never unassigned.
+func instanceV2SecurityGroupsChange(current, old, new []string, mode, ignored string) (unassign, assign []string) {
+	configured := make(map[string]bool, len(new))
+	for _, id := range new {
+		configured[id] = true
+	}
+	managed := make(map[string]bool, len(old))
+	for _, id := range old {
+		managed[id] = true
+	}
+
+	assigned := make(map[string]bool, len(current))
+	for _, id := range current {
+		assigned[id] = true
+		if configured[id] || id == ignored {
+			continue
+		}
+		if mode == instanceV2SecurityGroupsModeReplace || managed[id] {
+			unassign = append(unassign, id)
+		}
+	}
+	for _, id := range new {
+		if !assigned[id] {
+			assign = append(assign, id)
+		}
+	}
+	return unassign, assign
+}
+
+// instanceV2PortSecurityGroups returns security groups of the port to be kept in state. In append mode groups
+// assigned outside of terraform are skipped, so they don't show up in the diff.
+func instanceV2PortSecurityGroups(port []gcorecloud.ItemIDName, configured *schema.Set, mode, ignored string) []interface{} {
+	sgs := make([]interface{}, 0, len(port))
+	for _, sg := range port {
+		if sg.ID == ignored {
+			continue
+		}
+		if mode == instanceV2SecurityGroupsModeAppend && (configured == nil || !configured.Contains(sg.ID)) {
+			continue
+		}
+		sgs = append(sgs, sg.ID)
+	}
+	return sgs
+}
+
+// updateInstanceV2PortSecurityGroups makes security groups of the instance port match the interface configuration
+func updateInstanceV2PortSecurityGroups(client, sgClient *gcorecloud.ServiceClient, instanceID, portID string, current []gcorecloud.ItemIDName, old, new *schema.Set, mode, ignored string) error {
+	currentIDs := make([]string, len(current))
+	for i, sg := range current {
+		currentIDs[i] = sg.ID
+	}
+	unassign, assign := instanceV2SecurityGroupsChange(currentIDs, setToStrings(old), setToStrings(new), mode, ignored)
+
+	// security groups are assigned to the ports by name
+	if len(unassign) > 0 {
+		names, err := instanceV2SecurityGroupNames(sgClient, unassign, current)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Unassign security groups %v from port %s", names, portID)
+		opts := instances.SecurityGroupOpts{
+			PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{PortID: &portID, SecurityGroupNames: names}},
+		}
+		if err := instances.UnAssignSecurityGroup(client, instanceID, opts).ExtractErr(); err != nil {
+			return fmt.Errorf("cannot unassign security groups %v from port %s: %w", names, portID, err)
+		}
+	}
+	if len(assign) > 0 {
+		names, err := instanceV2SecurityGroupNames(sgClient, assign, current)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Assign security groups %v to port %s", names, portID)
+		opts := instances.SecurityGroupOpts{
+			PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{PortID: &portID, SecurityGroupNames: names}},
+		}
+		if err := instances.AssignSecurityGroup(client, instanceID, opts).ExtractErr(); err != nil {
+			return fmt.Errorf("cannot assign security groups %v to port %s: %w", names, portID, err)
+		}
+	}
+	return nil
+}
+
+// validateInstanceV2SecurityGroupNames fails the plan when security groups configured for an interface share a name.
+// Security groups of the existing ports are assigned by name, the SDK has no way to assign them by ID, so such groups
+// cannot be told apart. Interfaces are created with security groups by ID, only updates are checked.
+func validateInstanceV2SecurityGroupNames(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("interface") {
+		return nil
+	}
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("interface") {
+		return nil
+	}
+	ifaces := config.GetAttr("interface")
+	if ifaces.IsNull() || !ifaces.IsKnown() {
+		return nil
+	}
+	var groups [][]string
+	for it := ifaces.ElementIterator(); it.Next(); {
+		_, iface := it.Element()
+		if iface.IsNull() || !iface.IsKnown() || !iface.Type().HasAttribute("security_groups") {
+			continue
+		}
+		groups = append(groups, securityGroupConfigIDs(iface.GetAttr("security_groups")))
+	}
+	return validateSecurityGroupNamesUnique(m.(*Config).Provider, diff, groups)
+}
+
+// securityGroupConfigIDs returns known security group IDs of the raw config value
+func securityGroupConfigIDs(sgs cty.Value) []string {
+	if sgs.IsNull() || !sgs.IsKnown() {
+		return nil
+	}
+	ids := make([]string, 0, sgs.LengthInt())
+	for it := sgs.ElementIterator(); it.Next(); {
+		_, id := it.Element()
+		if id.IsNull() || !id.IsKnown() {
+			continue
+		}
+		ids = append(ids, id.AsString())
+	}
+	return ids
+}
+
+// validateSecurityGroupNamesUnique looks up names of the security groups and checks that groups of each port have
+// distinct names. The API is called only when a port has more than one security group.
+func validateSecurityGroupNamesUnique(provider *gcorecloud.ProviderClient, diff *schema.ResourceDiff, groups [][]string) error {
+	check := false
+	for _, ids := range groups {
+		if len(ids) > 1 {
+			check = true
+		}
+	}
+	if !check {
+		return nil
+	}
+
+	projectID, err := GetProject(provider, diff.Get("project_id").(int), diff.Get("project_name").(string))
+	if err != nil {
+		return err
+	}
+	regionID, err := GetRegion(provider, diff.Get("region_id").(int), diff.Get("region_name").(string))
+	if err != nil {
+		return err
+	}
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    securityGroupPoint,
+		Region:  regionID,
+		Project: projectID,
+		Version: versionPointV1,
+	})
+	if err != nil {
+		return err
+	}
+	sgs, err := securitygroups.ListAll(client, nil)
+	if err != nil {
+		return fmt.Errorf("cannot list security groups: %w", err)
+	}
+	names := make(map[string]string, len(sgs))
+	for _, sg := range sgs {
+		names[sg.ID] = sg.Name
+	}
+	return securityGroupsSharedName(groups, names)
+}
+
+// securityGroupsSharedName returns an error for the first pair of security groups of a port with the same name
+func securityGroupsSharedName(groups [][]string, names map[string]string) error {
+	for _, ids := range groups {
+		byName := make(map[string]string, len(ids))
+		for _, id := range ids {
+			name, ok := names[id]
+			if !ok {
+				continue
+			}
+			if other, ok := byName[name]; ok && other != id {
+				return fmt.Errorf("security groups %s and %s have the same name %q, security groups are assigned "+
+					"to the port by name and cannot be told apart", other, id, name)
+			}
+			byName[name] = id
+		}
+	}
+	return nil
+}
+
+// instanceV2SecurityGroupNames resolves security group IDs to names, names of groups assigned to the port are
+// taken from the port itself
+func instanceV2SecurityGroupNames(sgClient *gcorecloud.ServiceClient, ids []string, current []gcorecloud.ItemIDName) ([]string, error) {
+	known := make(map[string]string, len(current))
+	for _, sg := range current {
+		known[sg.ID] = sg.Name
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := known[id]; ok && name != "" {
+			names = append(names, name)
+			continue
+		}
+		sg, err := securitygroups.Get(sgClient, id).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get security group %s: %w", id, err)
+		}
+		names = append(names, sg.Name)
+	}
+	return names, nil
+}
+
+func setToStrings(set *schema.Set) []string {
+	if set == nil {
+		return nil
+	}
+	result := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+	return result
+}
@@ -0,0 +1,215 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInstanceV2OrderInterfaces(t *testing.T) {
+	state := []interface{}{
+		map[string]interface{}{"name": "private"},
+		map[string]interface{}{"name": "detached"},
+		map[string]interface{}{"name": "public"},
+	}
+	read := map[string]map[string]interface{}{
+		"public":  {"name": "public"},
+		"private": {"name": "private"},
+		"manual":  {"name": "manual"},
+	}
+
+	got := instanceV2OrderInterfaces(state, []string{"public", "manual", "private"}, read)
+	var names []string
+	for _, iface := range got {
+		names = append(names, iface.(map[string]interface{})["name"].(string))
+	}
+	if want := []string{"private", "public", "manual"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("instanceV2OrderInterfaces() got = %v, want %v", names, want)
+	}
+}
+
+func TestInstanceV2InterfaceReplaced(t *testing.T) {
+	tests := []struct {
+		name string
+		old  map[string]interface{}
+		new  map[string]interface{}
+		want bool
+	}{
+		{
+			name: "unchanged",
+			old:  map[string]interface{}{"type": "subnet", "subnet_id": "s1", "network_id": "n1"},
+			new:  map[string]interface{}{"type": "subnet", "subnet_id": "s1", "network_id": "n1"},
+		},
+		{
+			name: "subnet changed",
+			old:  map[string]interface{}{"type": "subnet", "subnet_id": "s1"},
+			new:  map[string]interface{}{"type": "subnet", "subnet_id": "s2"},
+			want: true,
+		},
+		{
+			name: "computed network of subnet interface",
+			old:  map[string]interface{}{"type": "subnet", "subnet_id": "s1", "network_id": "n1"},
+			new:  map[string]interface{}{"type": "subnet", "subnet_id": "s1", "network_id": "n2"},
+		},
+		{
+			name: "type changed",
+			old:  map[string]interface{}{"type": "external"},
+			new:  map[string]interface{}{"type": "any_subnet", "network_id": "n1"},
+			want: true,
+		},
+		{
+			name: "type unknown after import",
+			old:  map[string]interface{}{"type": "", "subnet_id": "s1"},
+			new:  map[string]interface{}{"type": "subnet", "subnet_id": "s2"},
+		},
+		{
+			name: "dual stack requested",
+			old:  map[string]interface{}{"type": "external"},
+			new:  map[string]interface{}{"type": "external", "ipv6_enabled": true},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		if got := instanceV2InterfaceReplaced(tt.old, tt.new); got != tt.want {
+			t.Errorf("%s: instanceV2InterfaceReplaced() got = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInstanceV2InterfaceIPFamily(t *testing.T) {
+	ipv4 := instances.PortIP{IPAddress: net.ParseIP("192.0.2.10")}
+	ipv6 := instances.PortIP{IPAddress: net.ParseIP("2001:db8::10")}
+	tests := []struct {
+		assignments []instances.PortIP
+		want        types.IPFamilyType
+	}{
+		{[]instances.PortIP{ipv4}, types.IPv4IPFamilyType},
+		{[]instances.PortIP{ipv6}, types.IPv6IPFamilyType},
+		{[]instances.PortIP{ipv4, ipv6}, types.DualStackIPFamilyType},
+	}
+	for _, tt := range tests {
+		if got := instanceV2InterfaceIPFamily(tt.assignments); got != tt.want {
+			t.Errorf("instanceV2InterfaceIPFamily(%v) = %s, want %s", tt.assignments, got, tt.want)
+		}
+	}
+
+	iface := map[string]interface{}{"ip_family": "", "ipv6_enabled": true}
+	if got := instanceInterfaceIPFamily(iface); got != types.DualStackIPFamilyType {
+		t.Errorf("instanceInterfaceIPFamily(%v) = %s, want %s", iface, got, types.DualStackIPFamilyType)
+	}
+}
+
+func TestReadInstanceV2AppPortsSecurityGroup(t *testing.T) {
+	ports := []instances.InstancePorts{{
+		ID: "port",
+		SecurityGroups: []gcorecloud.ItemIDName{
+			{ID: "default-id", Name: "default"},
+			{ID: "configured", Name: "web"},
+			{ID: "app", Name: "app-ports"},
+		},
+	}}
+	ifs := []interface{}{
+		map[string]interface{}{"security_groups": schema.NewSet(schema.HashString, []interface{}{"configured"})},
+	}
+
+	if got := readInstanceV2AppPortsSecurityGroup(ports, ifs, ""); got != "app" {
+		t.Errorf("readInstanceV2AppPortsSecurityGroup() = %q, want %q", got, "app")
+	}
+	if got := readInstanceV2AppPortsSecurityGroup(ports, ifs, "configured"); got != "configured" {
+		t.Errorf("readInstanceV2AppPortsSecurityGroup() = %q, want known %q", got, "configured")
+	}
+	if got := readInstanceV2AppPortsSecurityGroup(ports, ifs, "detached"); got != "app" {
+		t.Errorf("readInstanceV2AppPortsSecurityGroup() = %q, want %q", got, "app")
+	}
+}
+
+func TestInstanceV2SecurityGroupsChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      []string
+		old          []string
+		new          []string
+		mode         string
+		wantUnassign []string
+		wantAssign   []string
+	}{
+		{
+			name:         "replace removes unknown groups",
+			current:      []string{"default", "web", "manual"},
+			old:          []string{"default", "web"},
+			new:          []string{"default", "db"},
+			mode:         instanceV2SecurityGroupsModeReplace,
+			wantUnassign: []string{"web", "manual"},
+			wantAssign:   []string{"db"},
+		},
+		{
+			name:         "append keeps unknown groups",
+			current:      []string{"default", "web", "manual"},
+			old:          []string{"default", "web"},
+			new:          []string{"default", "db"},
+			mode:         instanceV2SecurityGroupsModeAppend,
+			wantUnassign: []string{"web"},
+			wantAssign:   []string{"db"},
+		},
+		{
+			name:    "application ports group is kept",
+			current: []string{"default", "app-ports"},
+			old:     []string{"default"},
+			new:     []string{"default"},
+			mode:    instanceV2SecurityGroupsModeReplace,
+		},
+	}
+	for _, tt := range tests {
+		unassign, assign := instanceV2SecurityGroupsChange(tt.current, tt.old, tt.new, tt.mode, "app-ports")
+		if !reflect.DeepEqual(unassign, tt.wantUnassign) || !reflect.DeepEqual(assign, tt.wantAssign) {
+			t.Errorf("%s: got unassign = %v, assign = %v, want unassign = %v, assign = %v", tt.name, unassign, assign, tt.wantUnassign, tt.wantAssign)
+		}
+	}
+}
+
+func TestInstanceV2SecurityGroupNames(t *testing.T) {
+	current := []gcorecloud.ItemIDName{{ID: "sg-1", Name: "default"}, {ID: "sg-2", Name: "web"}}
+
+	// names of the groups assigned to the port are known without API requests
+	names, err := instanceV2SecurityGroupNames(nil, []string{"sg-2", "sg-1"}, current)
+	if err != nil {
+		t.Fatalf("instanceV2SecurityGroupNames() error = %v", err)
+	}
+	if want := []string{"web", "default"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("instanceV2SecurityGroupNames() = %v, want %v", names, want)
+	}
+}
+
+func TestSecurityGroupsSharedName(t *testing.T) {
+	names := map[string]string{"sg-1": "default", "sg-2": "web", "sg-3": "web"}
+
+	if err := securityGroupsSharedName([][]string{{"sg-1", "sg-2"}, {"sg-3"}}, names); err != nil {
+		t.Errorf("groups with distinct names per port got error: %v", err)
+	}
+	if err := securityGroupsSharedName([][]string{{"sg-1", "sg-2", "sg-3"}}, names); err == nil {
+		t.Error("groups sharing a name on a port must fail")
+	}
+	// groups unknown to the listing are left to the API
+	if err := securityGroupsSharedName([][]string{{"sg-2", "sg-4"}}, names); err != nil {
+		t.Errorf("unknown group got error: %v", err)
+	}
+}
+
+func TestSecurityGroupConfigIDs(t *testing.T) {
+	sgs := cty.SetVal([]cty.Value{cty.StringVal("sg-1"), cty.UnknownVal(cty.String)})
+	if got, want := securityGroupConfigIDs(sgs), []string{"sg-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("securityGroupConfigIDs() = %v, want %v", got, want)
+	}
+	if got := securityGroupConfigIDs(cty.NullVal(cty.Set(cty.String))); len(got) != 0 {
+		t.Errorf("securityGroupConfigIDs() of null = %v, want empty", got)
+	}
+}
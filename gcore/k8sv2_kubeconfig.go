@@ -0,0 +1,76 @@
+package gcore
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+type k8sKubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// k8sKubeconfigContext holds cluster and user of the kubeconfig current context, certificates are base64 encoded
+type k8sKubeconfigContext struct {
+	Server                   string
+	CertificateAuthorityData string
+	ClientCertificateData    string
+	ClientKeyData            string
+	Token                    string
+}
+
+func parseK8sKubeconfig(kubeconfig string) (*k8sKubeconfigContext, error) {
+	var cfg k8sKubeconfig
+	if err := yaml.Unmarshal([]byte(kubeconfig), &cfg); err != nil {
+		return nil, err
+	}
+
+	var clusterName, userName string
+	for _, c := range cfg.Contexts {
+		if c.Name == cfg.CurrentContext {
+			clusterName, userName = c.Context.Cluster, c.Context.User
+		}
+	}
+
+	result := &k8sKubeconfigContext{}
+	for _, c := range cfg.Clusters {
+		if c.Name == clusterName {
+			result.Server = c.Cluster.Server
+			result.CertificateAuthorityData = c.Cluster.CertificateAuthorityData
+		}
+	}
+	if result.Server == "" {
+		return nil, fmt.Errorf("cluster of context %q not found", cfg.CurrentContext)
+	}
+
+	for _, u := range cfg.Users {
+		if u.Name == userName {
+			result.ClientCertificateData = u.User.ClientCertificateData
+			result.ClientKeyData = u.User.ClientKeyData
+			result.Token = u.User.Token
+		}
+	}
+	return result, nil
+}
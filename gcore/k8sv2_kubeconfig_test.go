@@ -0,0 +1,52 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+)
+
+func TestParseK8sKubeconfig(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: admin@cluster1
+contexts:
+- name: viewer@cluster1
+  context:
+    cluster: cluster1
+    user: viewer
+- name: admin@cluster1
+  context:
+    cluster: cluster1
+    user: admin
+clusters:
+- name: cluster1
+  cluster:
+    server: https://api.cluster1.example.com
+    certificate-authority-data: Q0EtREFUQQ==
+users:
+- name: viewer
+  user:
+    token: viewer-token
+- name: admin
+  user:
+    token: admin-token
+`
+	cfg, err := parseK8sKubeconfig(kubeconfig)
+	if err != nil {
+		t.Fatalf("parseK8sKubeconfig() error = %v", err)
+	}
+	want := k8sKubeconfigContext{
+		Server:                   "https://api.cluster1.example.com",
+		CertificateAuthorityData: "Q0EtREFUQQ==",
+		Token:                    "admin-token",
+	}
+	if *cfg != want {
+		t.Errorf("parseK8sKubeconfig() = %+v, want %+v", *cfg, want)
+	}
+
+	if _, err := parseK8sKubeconfig("current-context: missing\n"); err == nil {
+		t.Error("parseK8sKubeconfig() expected error for missing context")
+	}
+}
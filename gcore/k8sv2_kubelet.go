@@ -0,0 +1,115 @@
+package gcore
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	k8sKubeletMaxPods        = "maxPods"
+	k8sKubeletKubeReserved   = "kubeReserved"
+	k8sKubeletSystemReserved = "systemReserved"
+)
+
+// resourceK8sV2PoolKubeletConfig returns kubelet config of the pool with max_pods_per_node, kube_reserved and
+// system_reserved settings merged in
+func resourceK8sV2PoolKubeletConfig(pool map[string]interface{}) (map[string]string, error) {
+	result := map[string]string{}
+	if kubeletCfg, ok := pool["kubelet_config"].(map[string]interface{}); ok {
+		for k, v := range kubeletCfg {
+			result[k] = v.(string)
+		}
+	}
+
+	settings := map[string]string{}
+	if maxPods, _ := pool["max_pods_per_node"].(int); maxPods > 0 {
+		settings[k8sKubeletMaxPods] = strconv.Itoa(maxPods)
+	}
+	if reserved, _ := pool["kube_reserved"].(map[string]interface{}); len(reserved) > 0 {
+		settings[k8sKubeletKubeReserved] = formatK8sReservedResources(reserved)
+	}
+	if reserved, _ := pool["system_reserved"].(map[string]interface{}); len(reserved) > 0 {
+		settings[k8sKubeletSystemReserved] = formatK8sReservedResources(reserved)
+	}
+	for k, v := range settings {
+		if current, ok := result[k]; ok && current != v {
+			return nil, fmt.Errorf("pool %v: kubelet_config key %s = %q conflicts with the pool setting %q", pool["name"], k, current, v)
+		}
+		result[k] = v
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// resourceK8sV2PoolKubeletData sets kubelet config of the pool into data. Keys backing max_pods_per_node,
+// kube_reserved and system_reserved are moved to these attributes unless they are kept in kubelet_config
+// of the current state or their value doesn't parse.
+func resourceK8sV2PoolKubeletData(current map[string]interface{}, data map[string]interface{}, kubeletConfig map[string]string) {
+	currentCfg, _ := current["kubelet_config"].(map[string]interface{})
+
+	cfg := make(map[string]string, len(kubeletConfig))
+	for k, v := range kubeletConfig {
+		cfg[k] = v
+	}
+	data["max_pods_per_node"] = 0
+	data["kube_reserved"] = map[string]string{}
+	data["system_reserved"] = map[string]string{}
+	for _, key := range []string{k8sKubeletMaxPods, k8sKubeletKubeReserved, k8sKubeletSystemReserved} {
+		value, ok := cfg[key]
+		if !ok {
+			continue
+		}
+		if _, ok := currentCfg[key]; ok {
+			continue
+		}
+		switch key {
+		case k8sKubeletMaxPods:
+			maxPods, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			data["max_pods_per_node"] = maxPods
+		case k8sKubeletKubeReserved, k8sKubeletSystemReserved:
+			reserved, err := parseK8sReservedResources(value)
+			if err != nil {
+				continue
+			}
+			if key == k8sKubeletKubeReserved {
+				data["kube_reserved"] = reserved
+			} else {
+				data["system_reserved"] = reserved
+			}
+		}
+		delete(cfg, key)
+	}
+	data["kubelet_config"] = cfg
+}
+
+// formatK8sReservedResources formats reserved resources in kubelet flag format, eg. cpu=100m,memory=1Gi
+func formatK8sReservedResources(reserved map[string]interface{}) string {
+	resources := make([]string, 0, len(reserved))
+	for k, v := range reserved {
+		resources = append(resources, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(resources)
+	return strings.Join(resources, ",")
+}
+
+// parseK8sReservedResources parses reserved resources in kubelet flag format, eg. cpu=100m,memory=1Gi
+func parseK8sReservedResources(value string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, resource := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(resource, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("reserved resource %q is not in name=quantity format", resource)
+		}
+		result[k] = v
+	}
+	return result, nil
+}
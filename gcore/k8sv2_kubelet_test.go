@@ -0,0 +1,94 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceK8sV2PoolKubeletConfig(t *testing.T) {
+	pool := map[string]interface{}{
+		"name":              "pool1",
+		"kubelet_config":    map[string]interface{}{"imageGCHighThresholdPercent": "80"},
+		"max_pods_per_node": 60,
+		"kube_reserved":     map[string]interface{}{"memory": "1Gi", "cpu": "200m"},
+		"system_reserved":   map[string]interface{}{},
+	}
+	got, err := resourceK8sV2PoolKubeletConfig(pool)
+	if err != nil {
+		t.Fatalf("resourceK8sV2PoolKubeletConfig() error = %v", err)
+	}
+	want := map[string]string{
+		"imageGCHighThresholdPercent": "80",
+		"maxPods":                     "60",
+		"kubeReserved":                "cpu=200m,memory=1Gi",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resourceK8sV2PoolKubeletConfig() got = %v, want %v", got, want)
+	}
+
+	pool["kubelet_config"] = map[string]interface{}{"maxPods": "110"}
+	if _, err := resourceK8sV2PoolKubeletConfig(pool); err == nil {
+		t.Error("resourceK8sV2PoolKubeletConfig() conflicting maxPods got no error")
+	}
+}
+
+func TestResourceK8sV2PoolKubeletData(t *testing.T) {
+	kubeletConfig := map[string]string{
+		"imageGCHighThresholdPercent": "80",
+		"maxPods":                     "60",
+		"systemReserved":              "cpu=100m, memory=512Mi",
+	}
+
+	data := map[string]interface{}{}
+	resourceK8sV2PoolKubeletData(nil, data, kubeletConfig)
+	want := map[string]interface{}{
+		"kubelet_config":    map[string]string{"imageGCHighThresholdPercent": "80"},
+		"max_pods_per_node": 60,
+		"kube_reserved":     map[string]string{},
+		"system_reserved":   map[string]string{"cpu": "100m", "memory": "512Mi"},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("resourceK8sV2PoolKubeletData() got = %v, want %v", data, want)
+	}
+
+	// keys set in kubelet_config stay there
+	current := map[string]interface{}{"kubelet_config": map[string]interface{}{"maxPods": "60"}}
+	data = map[string]interface{}{}
+	resourceK8sV2PoolKubeletData(current, data, kubeletConfig)
+	if data["max_pods_per_node"] != 0 || data["kubelet_config"].(map[string]string)["maxPods"] != "60" {
+		t.Errorf("resourceK8sV2PoolKubeletData() moved maxPods kept in kubelet_config: %v", data)
+	}
+}
+
+func TestK8sReservedResourcesFormat(t *testing.T) {
+	reserved := map[string]interface{}{"memory": "1Gi", "cpu": "200m", "ephemeral-storage": "1Gi"}
+	value := formatK8sReservedResources(reserved)
+	if want := "cpu=200m,ephemeral-storage=1Gi,memory=1Gi"; value != want {
+		t.Errorf("formatK8sReservedResources() = %q, want %q", value, want)
+	}
+	got, err := parseK8sReservedResources(value)
+	if err != nil {
+		t.Fatalf("parseK8sReservedResources() error = %v", err)
+	}
+	want := map[string]string{"cpu": "200m", "ephemeral-storage": "1Gi", "memory": "1Gi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseK8sReservedResources() = %v, want %v", got, want)
+	}
+
+	for _, value := range []string{"", "cpu", "cpu=200m,memory", `{"cpu":"200m"}`, "=1Gi"} {
+		if _, err := parseK8sReservedResources(value); err == nil {
+			t.Errorf("parseK8sReservedResources(%q) expected error", value)
+		}
+	}
+
+	// values which don't parse stay in kubelet_config
+	data := map[string]interface{}{}
+	resourceK8sV2PoolKubeletData(nil, data, map[string]string{"kubeReserved": `{"cpu":"200m"}`, "maxPods": "many"})
+	wantCfg := map[string]string{"kubeReserved": `{"cpu":"200m"}`, "maxPods": "many"}
+	if !reflect.DeepEqual(data["kubelet_config"], wantCfg) || data["max_pods_per_node"] != 0 {
+		t.Errorf("resourceK8sV2PoolKubeletData() = %v, want kubelet_config %v", data, wantCfg)
+	}
+}
@@ -21,18 +21,20 @@ import (
 )
 
 const (
-	ProviderOptPermanentToken    = "permanent_api_token"
-	ProviderOptSkipCredsAuthErr  = "ignore_creds_auth_error"
-	ProviderOptSingleApiEndpoint = "api_endpoint"
-	DefaultUserAgent             = "terraform-provider/%s"
+	ProviderOptPermanentToken     = "permanent_api_token"
+	ProviderOptSkipCredsAuthErr   = "ignore_creds_auth_error"
+	ProviderOptSingleApiEndpoint  = "api_endpoint"
+	ProviderOptStrictDeprecations = "strict_deprecations"
+	DefaultUserAgent              = "terraform-provider/%s"
 
-	lifecyclePolicyResource = "gcore_lifecyclepolicy"
+	lifecyclePolicyResource   = "gcore_lifecyclepolicy"
+	cdnLimiterProfileResource = "gcore_cdn_rate_limiter_profile"
 )
 
 var AppVersion = "dev"
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"user_name": {
 				Type:     schema.TypeString,
@@ -126,6 +128,12 @@ func Provider() *schema.Provider {
 				Description: "Client ID. Can also be set with the GCORE_CLIENT_ID environment variable.",
 				DefaultFunc: schema.EnvDefaultFunc("GCORE_CLIENT_ID", ""),
 			},
+			ProviderOptStrictDeprecations: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Fail the plan instead of warning when deprecated resources, data sources or attributes are used, e.g. in CI. Can also be set with the GCORE_STRICT_DEPRECATIONS environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_STRICT_DEPRECATIONS", false),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"gcore_ai_cluster":          resourceAICluster(),
@@ -135,6 +143,7 @@ func Provider() *schema.Provider {
 			"gcore_router":              resourceRouter(),
 			"gcore_instance":            resourceInstance(),
 			"gcore_instancev2":          resourceInstanceV2(),
+			"gcore_instance_interface":  resourceInstanceInterface(),
 			"gcore_keypair":             resourceKeypair(),
 			"gcore_reservedfixedip":     resourceReservedFixedIP(),
 			"gcore_floatingip":          resourceFloatingIP(),
@@ -148,6 +157,7 @@ func Provider() *schema.Provider {
 			"gcore_snapshot":            resourceSnapshot(),
 			"gcore_servergroup":         resourceServerGroup(),
 			"gcore_k8sv2":               resourceK8sV2(),
+			"gcore_k8sv2_pool":          resourceK8sV2Pool(),
 			"gcore_secret":              resourceSecret(),
 			"gcore_laas_topic":          resourceLaaSTopic(),
 			"gcore_faas_namespace":      resourceFaaSNamespace(),
@@ -157,6 +167,9 @@ func Provider() *schema.Provider {
 			"gcore_storage_s3_bucket":   resourceStorageS3Bucket(),
 			DNSZoneResource:             resourceDNSZone(),
 			DNSZoneRecordResource:       resourceDNSZoneRecord(),
+			DNSPTRRecordResource:        resourceDNSPTRRecord(),
+			DNSLBPoolResource:           resourceDNSLBPool(),
+			DNSSwitchoverResource:       resourceDNSSwitchover(),
 			"gcore_storage_sftp":        resourceStorageSFTP(),
 			"gcore_storage_sftp_key":    resourceStorageSFTPKey(),
 			"gcore_cdn_resource":        resourceCDNResource(),
@@ -166,16 +179,22 @@ func Provider() *schema.Provider {
 			"gcore_cdn_rule":            resourceCDNRule(),
 			"gcore_cdn_sslcert":         resourceCDNCert(),
 			"gcore_cdn_rule_template":   resourceRuleTemplate(),
+			cdnLimiterProfileResource:   resourceCDNRateLimiterProfile(),
 			"gcore_cdn_cacert":          resourceCDNCACert(),
 			lifecyclePolicyResource:     resourceLifecyclePolicy(),
 			"gcore_ddos_protection":     resourceDDoSProtection(),
+			"gcore_iam_user":            resourceIAMUser(),
+			"gcore_iam_api_token":       resourceIAMAPIToken(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"gcore_ai_cluster":             dataSourceAICluster(),
+			"gcore_ai_flavors":             dataSourceAIFlavors(),
+			"gcore_ai_images":              dataSourceAIImages(),
 			"gcore_project":                dataSourceProject(),
 			"gcore_region":                 dataSourceRegion(),
 			"gcore_securitygroup":          dataSourceSecurityGroup(),
 			"gcore_image":                  dataSourceImage(),
+			"gcore_images":                 dataSourceImages(),
 			"gcore_volume":                 dataSourceVolume(),
 			"gcore_network":                dataSourceNetwork(),
 			"gcore_subnet":                 dataSourceSubnet(),
@@ -185,6 +204,9 @@ func Provider() *schema.Provider {
 			"gcore_lblistener":             dataSourceLBListener(),
 			"gcore_lbpool":                 dataSourceLBPool(),
 			"gcore_instance":               dataSourceInstance(),
+			"gcore_instancev2":             dataSourceInstanceV2(),
+			"gcore_instances":              dataSourceInstances(),
+			"gcore_instance_console_log":   dataSourceInstanceConsoleLog(),
 			"gcore_floatingip":             dataSourceFloatingIP(),
 			"gcore_storage_s3":             dataSourceStorageS3(),
 			"gcore_storage_s3_bucket":      dataSourceStorageS3Bucket(),
@@ -194,6 +216,9 @@ func Provider() *schema.Provider {
 			"gcore_servergroup":            dataSourceServerGroup(),
 			"gcore_k8sv2":                  dataSourceK8sV2(),
 			"gcore_k8sv2_kubeconfig":       dataSourceK8sV2KubeConfig(),
+			"gcore_k8sv2_certificates":     dataSourceK8sV2Certificates(),
+			"gcore_k8sv2_events":           dataSourceK8sV2Events(),
+			"gcore_account_limits":         dataSourceAccountLimits(),
 			"gcore_secret":                 dataSourceSecret(),
 			"gcore_laas_hosts":             dataSourceLaaSHosts(),
 			"gcore_laas_status":            dataSourceLaaSStatus(),
@@ -203,9 +228,16 @@ func Provider() *schema.Provider {
 			"gcore_ddos_profile_template":  dataSourceDDoSProfileTemplate(),
 			"gcore_cdn_shielding_location": dataOriginShieldingLocation(),
 			"gcore_cdn_preset":             dataPreset(),
+			"gcore_cdn_rule":               dataSourceCDNRule(),
+			"gcore_cdn_origingroup":        dataSourceCDNOriginGroup(),
+			DNSZoneExportDataSource:        dataSourceDNSZoneExport(),
+			DNSZonesDataSource:             dataSourceDNSZones(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+	applyStrictDeprecations(p)
+
+	return p
 }
 
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -291,9 +323,12 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 
 	provider.SetDebug(os.Getenv("TF_LOG") == "DEBUG")
 	config := Config{
-		Provider:  provider,
-		CDNClient: cdnService,
-		CDNMutex:  &sync.Mutex{},
+		Provider:    provider,
+		CDNClient:   cdnService,
+		CDNMutex:    &sync.Mutex{},
+		PlatformAPI: platform,
+
+		StrictDeprecations: d.Get(ProviderOptStrictDeprecations).(bool),
 	}
 
 	userAgent := fmt.Sprintf("terraform/%s", version.Version)
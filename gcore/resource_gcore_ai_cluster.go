@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,9 +20,11 @@ import (
 	volumesV2 "github.com/G-Core/gcorelabscloud-go/gcore/volume/v2/volumes"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -30,6 +33,8 @@ const (
 	AIClusterSuspendTimeout  int = 300
 
 	AIClusterPoint = "ai/clusters"
+	AIFlavorsPoint = "ai/flavors"
+	AIImagesPoint  = "ai/images"
 	TaskPoint      = "tasks"
 )
 
@@ -53,7 +58,11 @@ func resourceAICluster() *schema.Resource {
 		ReadContext:   resourceAIClusterRead,
 		UpdateContext: resourceAIClusterUpdate,
 		DeleteContext: resourceAIClusterDelete,
-		Description:   "Represent instance",
+		CustomizeDiff: customdiff.All(
+			validateAIClusterSuspendWindow,
+			validateAIClusterSecurityGroups,
+		),
+		Description: "Represent instance",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, clusterID, err := ImportStringParser(d.Id())
@@ -110,10 +119,57 @@ func resourceAICluster() *schema.Resource {
 				Required:    true,
 			},
 			"cluster_status": {
-				Type:        schema.TypeString,
-				Description: "AI Cluster status",
-				Optional:    true,
-				Computed:    true,
+				Type:             schema.TypeString,
+				Description:      "AI Cluster status",
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressAIClusterStatusInSuspendWindow,
+			},
+			"suspend_window": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: `
+Daily window when the cluster is suspended outside of terraform, eg. by a scheduled job at night. Within the window
+a suspended cluster is not planned to be resumed to cluster_status 'ACTIVE', outside of it the cluster_status is
+applied as usual. The provider doesn't suspend the cluster at the start of the window.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:         schema.TypeString,
+							Description:  "Start of the window in HH:MM format",
+							Required:     true,
+							ValidateFunc: validation.StringMatch(aiClusterWindowTimeRegexp, "must be in HH:MM format"),
+						},
+						"end": {
+							Type:         schema.TypeString,
+							Description:  "End of the window in HH:MM format, it must differ from start. The window ends on the next day if it is before start",
+							Required:     true,
+							ValidateFunc: validation.StringMatch(aiClusterWindowTimeRegexp, "must be in HH:MM format"),
+						},
+						"timezone": {
+							Type:        schema.TypeString,
+							Description: "IANA time zone of start and end, eg. Europe/Luxembourg",
+							Optional:    true,
+							Default:     "UTC",
+							ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+								if _, err := time.LoadLocation(i.(string)); err != nil {
+									return nil, []error{fmt.Errorf("%s: %w", k, err)}
+								}
+								return nil, nil
+							},
+						},
+						"days": {
+							Type:        schema.TypeSet,
+							Description: "Days the window starts on: mon, tue, wed, thu, fri, sat, sun. Every day if not set",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}, false),
+							},
+						},
+					},
+				},
 			},
 			"task_id": {
 				Type:        schema.TypeString,
@@ -145,6 +201,19 @@ func resourceAICluster() *schema.Resource {
 				Description: "Image ID",
 				Required:    true,
 			},
+			"capacity_check": {
+				Type:        schema.TypeBool,
+				Description: "Check that the flavor has free capacity and the account has enough quota in the region before the cluster is created or resized",
+				Optional:    true,
+				Default:     false,
+			},
+			"capacity_wait_timeout": {
+				Type:         schema.TypeInt,
+				Description:  "Time in seconds to wait for free capacity of the flavor before the cluster is created or resized. The apply fails at once if it is 0",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"image_name": {
 				Type:        schema.TypeString,
 				Description: "Image name",
@@ -272,7 +341,7 @@ func resourceAICluster() *schema.Resource {
 			},
 			"security_group": {
 				Type:        schema.TypeSet,
-				Description: "Security groups attached to the cluster",
+				Description: "Security groups attached to the cluster. It conflicts with security_groups of the interfaces",
 				Optional:    true,
 				Set:         aiSgHashID,
 				Elem: &schema.Resource{
@@ -294,19 +363,20 @@ func resourceAICluster() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"type": {
 							Type:        schema.TypeString,
-							Description: "Network type",
+							Description: fmt.Sprintf("Network type. Available values are '%s', '%s', '%s', '%s'", types.ExternalInterfaceType, types.SubnetInterfaceType, types.AnySubnetInterfaceType, types.ReservedFixedIpType),
 							Optional:    true,
 							ValidateDiagFunc: func(val interface{}, key cty.Path) diag.Diagnostics {
 								v := val.(string)
-								if types.InterfaceType(v) == types.ExternalInterfaceType || types.InterfaceType(v) == types.SubnetInterfaceType {
+								switch types.InterfaceType(v) {
+								case types.ExternalInterfaceType, types.SubnetInterfaceType, types.AnySubnetInterfaceType, types.ReservedFixedIpType:
 									return diag.Diagnostics{}
 								}
-								return diag.Errorf("wrong source type %s, now available values are '%s', '%s'", v, types.ExternalInterfaceType, types.SubnetInterfaceType)
+								return diag.Errorf("wrong source type %s, now available values are '%s', '%s', '%s', '%s'", v, types.ExternalInterfaceType, types.SubnetInterfaceType, types.AnySubnetInterfaceType, types.ReservedFixedIpType)
 							},
 						},
 						"network_id": {
 							Type:        schema.TypeString,
-							Description: "Network ID",
+							Description: "Network ID, required if type is 'subnet' or 'any_subnet'",
 							Optional:    true,
 							Computed:    true,
 						},
@@ -317,9 +387,16 @@ func resourceAICluster() *schema.Resource {
 						},
 						"port_id": {
 							Type:        schema.TypeString,
-							Description: "Network ID the subnet belongs to. Port will be plugged in this network",
+							Description: "Port ID of the reserved fixed IP, required if type is 'reserved_fixed_ip'",
 							Optional:    true,
 						},
+						"security_groups": {
+							Type:        schema.TypeList,
+							Description: "Security group IDs of the interface ports, allows e.g. the management network to differ from the training fabric. It conflicts with security_group of the cluster",
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -355,6 +432,7 @@ func resourceAICluster() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"node_addresses": aiClusterNodeAddressesSchema(),
 			"poplar_servers": {
 				Type:        schema.TypeList,
 				Description: "Poplar servers",
@@ -516,11 +594,17 @@ func extractAIClusterInterfacesMap(interfaces []interface{}) ([]instances.Interf
 		case ifaceMap["type"].(string) == string(types.ReservedFixedIpType):
 			{
 				IfaceOpts.Type = types.ReservedFixedIpType
-				IfaceOpts.SubnetID = ifaceMap["port_id"].(string)
+				IfaceOpts.PortID = ifaceMap["port_id"].(string)
 			}
 		}
+		rawSgsID, _ := ifaceMap["security_groups"].([]interface{})
+		sgs := make([]gcorecloud.ItemID, len(rawSgsID))
+		for i, sgID := range rawSgsID {
+			sgs[i] = gcorecloud.ItemID{ID: sgID.(string)}
+		}
 		Interfaces[index] = instances.InterfaceInstanceCreateOpts{
-			InterfaceOpts: IfaceOpts,
+			InterfaceOpts:  IfaceOpts,
+			SecurityGroups: sgs,
 		}
 	}
 	return Interfaces, nil
@@ -641,6 +725,9 @@ func resourceAIClusterCreate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkAIClusterCapacity(ctx, provider, d, createOpts.Flavor); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Printf("[DEBUG] AI cluster create options: %+v", createOpts)
 	results, err := ai.Create(client, createOpts).Extract()
 	if err != nil {
@@ -782,27 +869,159 @@ func AICluserSgRefreshedFunc(client *gcorecloud.ServiceClient, clusterID string,
 	}
 }
 
+// getDetachOptions finds the instance interface matching the configured one by the same attributes that identify
+// the port of an instancev2 interface: port_id for reserved fixed IP, network_id for any_subnet and subnet_id for subnet.
 func getDetachOptions(instanceInterfaces []instances.Interface, detachIface ai.AttachInterfaceOpts) (*ai.DetachInterfaceOpts, error) {
-	allDetachMap := make(map[string]ai.DetachInterfaceOpts, len(instanceInterfaces))
 	for _, instanceIface := range instanceInterfaces {
-		if detachIface.Type == types.ExternalInterfaceType && instanceIface.NetworkDetails.External {
-			return &ai.DetachInterfaceOpts{
-				PortID:    instanceIface.PortID,
-				IpAddress: instanceIface.IPAssignments[0].IPAddress.String(),
-			}, nil
+		if len(instanceIface.IPAssignments) == 0 {
+			continue
+		}
+		detachOpts := &ai.DetachInterfaceOpts{
+			PortID:    instanceIface.PortID,
+			IpAddress: instanceIface.IPAssignments[0].IPAddress.String(),
 		}
-		for _, ipAssignment := range instanceIface.IPAssignments {
-			allDetachMap[ipAssignment.SubnetID] = ai.DetachInterfaceOpts{
-				PortID:    instanceIface.PortID,
-				IpAddress: ipAssignment.IPAddress.String(),
+		switch detachIface.Type {
+		case types.ExternalInterfaceType:
+			if instanceIface.NetworkDetails.External {
+				return detachOpts, nil
+			}
+		case types.ReservedFixedIpType:
+			if instanceIface.PortID == detachIface.PortID {
+				return detachOpts, nil
+			}
+		case types.AnySubnetInterfaceType:
+			if instanceIface.NetworkID == detachIface.NetworkID {
+				return detachOpts, nil
+			}
+		default:
+			for _, ipAssignment := range instanceIface.IPAssignments {
+				if ipAssignment.SubnetID == detachIface.SubnetID {
+					detachOpts.IpAddress = ipAssignment.IPAddress.String()
+					return detachOpts, nil
+				}
 			}
 		}
 	}
-	if detachOpts, found := allDetachMap[detachIface.SubnetID]; found {
-		return &detachOpts, nil
-	} else {
-		return nil, fmt.Errorf("couldn't found detach options for interface: %v", detachIface)
+	return nil, fmt.Errorf("couldn't found detach options for interface: %v", detachIface)
+}
+
+// validateAIClusterSecurityGroups forbids setting security groups both for the cluster and per interface,
+// the cluster level update would reassign groups set for the interface ports
+func validateAIClusterSecurityGroups(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if aiClusterSecurityGroupsConflict(diff.GetRawConfig()) {
+		return fmt.Errorf("security_group conflicts with security_groups of the interfaces, set security groups either for the cluster or per interface")
 	}
+	return nil
+}
+
+// aiClusterSecurityGroupsConflict reports whether the config sets both security_group and security_groups of an interface
+func aiClusterSecurityGroupsConflict(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return false
+	}
+	notEmpty := func(v cty.Value) bool {
+		return !v.IsNull() && (!v.IsKnown() || v.LengthInt() > 0)
+	}
+	if !config.Type().HasAttribute("security_group") || !notEmpty(config.GetAttr("security_group")) {
+		return false
+	}
+	if !config.Type().HasAttribute("interface") {
+		return false
+	}
+	ifaces := config.GetAttr("interface")
+	if ifaces.IsNull() || !ifaces.IsKnown() {
+		return false
+	}
+	for it := ifaces.ElementIterator(); it.Next(); {
+		_, iface := it.Element()
+		if iface.IsNull() || !iface.IsKnown() || !iface.Type().HasAttribute("security_groups") {
+			continue
+		}
+		if notEmpty(iface.GetAttr("security_groups")) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateAIClusterInterfaceSecurityGroups reassigns security groups of every poplar server port
+// according to per-interface security_groups
+func updateAIClusterInterfaceSecurityGroups(instanceClient, sgClient *gcorecloud.ServiceClient, poplarInstances, ifaces []interface{}) error {
+	for _, instance := range poplarInstances {
+		instanceID := instance.(map[string]interface{})["instance_id"].(string)
+		interfaceList, err := instances.ListInterfacesAll(instanceClient, instanceID)
+		if err != nil {
+			return err
+		}
+		instancePorts, err := instances.ListPortsAll(instanceClient, instanceID)
+		if err != nil {
+			return err
+		}
+		for _, iface := range ifaces {
+			sgIDs, _ := iface.(map[string]interface{})["security_groups"].([]interface{})
+			if len(sgIDs) == 0 {
+				continue
+			}
+			// detach options hold port of the interface
+			ifaceOpts, err := getDetachOptions(interfaceList, map2AttachInterfaceOpts([]interface{}{iface})[0])
+			if err != nil {
+				return err
+			}
+			port, err := findInstancePort(ifaceOpts.PortID, instancePorts)
+			if err != nil {
+				return fmt.Errorf("find port %s of ai instance %s: %w", ifaceOpts.PortID, instanceID, err)
+			}
+
+			sgToDetach := make([]string, 0)
+			for _, sg := range port.SecurityGroups {
+				if !slices.ContainsFunc(sgIDs, func(id interface{}) bool {
+					return id.(string) == sg.ID
+				}) {
+					sgToDetach = append(sgToDetach, sg.Name)
+				}
+			}
+			sgToAttach := make([]string, 0)
+			for _, sgID := range sgIDs {
+				if slices.ContainsFunc(port.SecurityGroups, func(s gcorecloud.ItemIDName) bool {
+					return s.ID == sgID.(string)
+				}) {
+					continue
+				}
+				secGroup, err := securitygroups.Get(sgClient, sgID.(string)).Extract()
+				if err != nil {
+					return err
+				}
+				sgToAttach = append(sgToAttach, secGroup.Name)
+			}
+
+			portID := ifaceOpts.PortID
+			if len(sgToDetach) > 0 {
+				log.Printf("[DEBUG] unassing from ai instance %s port %s security groups %v", instanceID, portID, sgToDetach)
+				detachOpts := instances.SecurityGroupOpts{
+					PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{
+						PortID:             &portID,
+						SecurityGroupNames: sgToDetach,
+					}},
+				}
+				if err := instances.UnAssignSecurityGroup(instanceClient, instanceID, detachOpts).ExtractErr(); err != nil {
+					return fmt.Errorf("unassign security groups %v: %w", sgToDetach, err)
+				}
+			}
+			if len(sgToAttach) > 0 {
+				log.Printf("[DEBUG] assing to ai instance %s port %s security groups %v", instanceID, portID, sgToAttach)
+				attachOpts := instances.SecurityGroupOpts{
+					PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{
+						PortID:             &portID,
+						SecurityGroupNames: sgToAttach,
+					}},
+				}
+				if err := instances.AssignSecurityGroup(instanceClient, instanceID, attachOpts).ExtractErr(); err != nil {
+					return fmt.Errorf("assign security groups %v: %w", sgToAttach, err)
+				}
+			}
+		}
+	}
+	return nil
 }
 
 var IsResize bool = false
@@ -919,6 +1138,11 @@ func resourceAIClusterUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			resizeOpts.Metadata[metaKey] = metaValue.(string)
 		}
 
+		if d.HasChange("flavor") {
+			if err := checkAIClusterCapacity(ctx, provider, d, resizeOpts.Flavor); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 		log.Printf("[DEBUG] AI cluster resize options: %+v", resizeOpts)
 		results, err := ai.Resize(clientV1, clusterID, resizeOpts).Extract()
 		if err != nil {
@@ -1055,6 +1279,22 @@ func resourceAIClusterUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		}
 	}
 
+	if d.HasChange("interface") && !IsResize {
+		instanceClient, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		poplarInstances := d.Get("poplar_servers").([]interface{})
+		ifaces := d.Get("interface").([]interface{})
+		if err := updateAIClusterInterfaceSecurityGroups(instanceClient, sgClient, poplarInstances, ifaces); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("security_group") && !IsResize {
 		sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
 		if err != nil {
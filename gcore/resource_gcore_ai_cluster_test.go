@@ -18,10 +18,6 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
 )
 
-const (
-	AIImagesPoint = "ai/images"
-)
-
 func TestAccAIClusterResource(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode")
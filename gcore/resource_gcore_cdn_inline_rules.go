@@ -0,0 +1,216 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/AlekSi/pointer"
+	gcdn "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/rules"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var cdnInlineRuleSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	Description: "Rules of the CDN resource managed within the resource. Only rules defined here are managed, rules of the `gcore_cdn_rule` resource are kept untouched. Rule names must be unique within the CDN resource, including names of `gcore_cdn_rule` rules.",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Rule ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Rule name, unique within the CDN resource",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The setting allows to enable or disable a Rule. If not specified, it will be enabled.",
+			},
+			"rule": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A pattern that defines when the rule is triggered. By default, we add a leading forward slash to any rule pattern. Specify a pattern without a forward slash.",
+			},
+			"rule_type": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Type of rule. Type 0 — RegEx. Must start with '^/' or '/'. Type 1 — RegEx. Legacy type.",
+			},
+			"origin_group": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the Origins Group used by the rule. If not specified, it will be inherit from resource.",
+			},
+			"origin_protocol": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "This option defines the protocol that will be used by CDN servers to request content from an origin source. If not specified, it will be inherit from resource. Possible values are: HTTPS, HTTP, MATCH.",
+			},
+			"weight": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Rule weight that determines rule execution order: from the smallest (0) to the highest.",
+			},
+			"options": ruleOptionsSchema,
+		},
+	},
+}
+
+func validateCDNInlineRules(diff *schema.ResourceDiff) error {
+	names := map[string]bool{}
+	for _, r := range diff.Get("rule").([]interface{}) {
+		name := r.(map[string]interface{})["name"].(string)
+		if name == "" {
+			continue
+		}
+		if names[name] {
+			return fmt.Errorf("rule name %s is used more than once", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// syncCDNInlineRules applies changes of the inline rules: removed rules are deleted first, then changed rules
+// are updated and new rules are created one by one. It returns the rules with IDs set.
+func syncCDNInlineRules(ctx context.Context, client rules.RulesService, resourceID int64, old, new []interface{}) ([]interface{}, error) {
+	oldByName := make(map[string]map[string]interface{}, len(old))
+	for _, r := range old {
+		rule := r.(map[string]interface{})
+		oldByName[rule["name"].(string)] = rule
+	}
+	newByName := make(map[string]bool, len(new))
+	for _, r := range new {
+		newByName[r.(map[string]interface{})["name"].(string)] = true
+	}
+
+	for name, rule := range oldByName {
+		if newByName[name] {
+			continue
+		}
+		id := int64(rule["id"].(int))
+		log.Printf("[DEBUG] Deleting CDN inline rule %s (id=%d)", name, id)
+		if err := client.Delete(ctx, resourceID, id); err != nil {
+			return nil, fmt.Errorf("delete rule %s: %w", name, err)
+		}
+	}
+
+	for _, r := range new {
+		rule := r.(map[string]interface{})
+		oldRule, ok := oldByName[rule["name"].(string)]
+		if !ok {
+			continue
+		}
+		rule["id"] = oldRule["id"]
+		if reflect.DeepEqual(oldRule, rule) {
+			continue
+		}
+		createReq := cdnInlineRuleRequest(rule)
+		req := rules.UpdateRequest{
+			Name:                   createReq.Name,
+			Active:                 createReq.Active,
+			Rule:                   createReq.Rule,
+			RuleType:               createReq.RuleType,
+			Weight:                 createReq.Weight,
+			OriginGroup:            createReq.OriginGroup,
+			OverrideOriginProtocol: createReq.OverrideOriginProtocol,
+			Options:                createReq.Options,
+		}
+		if _, err := client.Update(ctx, resourceID, int64(rule["id"].(int)), &req); err != nil {
+			return nil, fmt.Errorf("update rule %s: %w", rule["name"], err)
+		}
+	}
+
+	// rules created before a failure keep their IDs
+	for _, r := range new {
+		rule := r.(map[string]interface{})
+		if _, ok := oldByName[rule["name"].(string)]; ok {
+			continue
+		}
+		req := cdnInlineRuleRequest(rule)
+		result, err := client.Create(ctx, resourceID, &req)
+		if err != nil {
+			return new, fmt.Errorf("create rule %s: %w", rule["name"], err)
+		}
+		log.Printf("[DEBUG] Created CDN inline rule %s (id=%d)", rule["name"], result.ID)
+		rule["id"] = int(result.ID)
+	}
+
+	return new, nil
+}
+
+func cdnInlineRuleRequest(rule map[string]interface{}) rules.CreateRequest {
+	req := rules.CreateRequest{
+		Name:     rule["name"].(string),
+		Active:   rule["active"].(bool),
+		Rule:     rule["rule"].(string),
+		RuleType: rule["rule_type"].(int),
+		Weight:   rule["weight"].(int),
+		Options:  listToOptions(rule["options"].([]interface{})),
+	}
+	if originGroup := rule["origin_group"].(int); originGroup > 0 {
+		req.OriginGroup = pointer.ToInt(originGroup)
+	}
+	if originProtocol := rule["origin_protocol"].(string); originProtocol != "" {
+		req.OverrideOriginProtocol = pointer.ToString(originProtocol)
+	}
+	return req
+}
+
+// readCDNInlineRules refreshes the inline rules by their IDs.
+// Rules deleted outside of Terraform are dropped to be created again.
+func readCDNInlineRules(ctx context.Context, client rules.RulesService, resourceID int64, current []interface{}) ([]interface{}, error) {
+	data := make([]interface{}, 0, len(current))
+	for _, c := range current {
+		id := int64(c.(map[string]interface{})["id"].(int))
+		if id == 0 {
+			continue
+		}
+		r, err := client.Get(ctx, resourceID, id)
+		if err != nil {
+			var errResp *gcdn.ErrorResponse
+			if errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound {
+				log.Printf("[WARN] CDN inline rule %d not found, removing from state", id)
+				continue
+			}
+			return nil, fmt.Errorf("get rule %d: %w", id, err)
+		}
+		data = append(data, cdnInlineRuleData(r))
+	}
+	return data, nil
+}
+
+func cdnInlineRuleData(r *rules.Rule) map[string]interface{} {
+	rule := map[string]interface{}{
+		"id":              int(r.ID),
+		"name":            r.Name,
+		"active":          r.Active,
+		"rule":            r.Pattern,
+		"rule_type":       r.Type,
+		"origin_group":    0,
+		"origin_protocol": "",
+		"weight":          r.Weight,
+		"options":         []interface{}{},
+	}
+	if r.Options != nil {
+		rule["options"] = optionsToList(r.Options)
+	}
+	if r.OriginGroup != nil {
+		rule["origin_group"] = *r.OriginGroup
+	}
+	if r.OverrideOriginProtocol != nil {
+		rule["origin_protocol"] = *r.OverrideOriginProtocol
+	}
+	return rule
+}
@@ -0,0 +1,264 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gcdn "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCDNRateLimiterProfile() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the rate limiter profile.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The setting allows to enable or disable the request limiter on the CDN resources.",
+			},
+			"rate": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Maximum request rate.",
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Maximum number of requests exceeding the rate that are queued.",
+			},
+			"rate_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "r/s",
+				ValidateFunc: validation.StringInSlice([]string{"r/s", "r/m"}, false),
+				Description:  "Units of the rate. Possible values are: r/s, r/m.",
+			},
+			"delay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Number of queued requests that are served without delay.",
+			},
+			"resource_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of CDN resources the profile is applied to. Changes of the profile are propagated to all of them.",
+			},
+		},
+		CreateContext: resourceCDNRateLimiterProfileCreate,
+		ReadContext:   resourceCDNRateLimiterProfileRead,
+		UpdateContext: resourceCDNRateLimiterProfileUpdate,
+		DeleteContext: resourceCDNRateLimiterProfileDelete,
+		Description:   "Represent request limiter settings shared by several CDN resources. The `request_limiter` option of these resources is managed by the profile.",
+	}
+}
+
+func resourceCDNRateLimiterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CDN Rate Limiter Profile creating")
+	config := m.(*Config)
+
+	config.CDNMutex.Lock()
+	defer config.CDNMutex.Unlock()
+
+	limiter := cdnRateLimiterProfileLimiter(d)
+	for _, resourceID := range d.Get("resource_ids").(*schema.Set).List() {
+		if err := setCDNResourceRequestLimiter(ctx, config, int64(resourceID.(int)), limiter); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(id.UniqueId())
+
+	log.Printf("[DEBUG] Finish CDN Rate Limiter Profile creating (id=%s)\n", d.Id())
+	return resourceCDNRateLimiterProfileRead(ctx, d, m)
+}
+
+func resourceCDNRateLimiterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start CDN Rate Limiter Profile reading (id=%s)\n", d.Id())
+	config := m.(*Config)
+	client := config.CDNClient
+
+	var limiter *gcdn.RequestLimiter
+	ids := d.Get("resource_ids").(*schema.Set).List()
+	if len(ids) == 0 {
+		// on import the ID is a comma separated list of CDN resource IDs the profile is applied to,
+		// the limiter settings are taken from the first of them
+		var err error
+		if ids, err = parseCDNRateLimiterProfileID(d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		limiter = cdnRateLimiterProfileLimiter(d)
+	}
+
+	found := 0
+	resourceIDs := make([]interface{}, 0)
+	for _, resourceID := range ids {
+		result, err := client.Resources().Get(ctx, int64(resourceID.(int)))
+		if err != nil {
+			var errResp *gcdn.ErrorResponse
+			if errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound {
+				log.Printf("[WARN] CDN resource %d of the rate limiter profile not found", resourceID)
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		found++
+		if result.Options == nil || result.Options.RequestLimiter == nil {
+			continue
+		}
+		if limiter == nil {
+			limiter = result.Options.RequestLimiter
+			d.Set("enabled", limiter.Enabled)
+			d.Set("rate", limiter.Rate)
+			d.Set("burst", limiter.Burst)
+			d.Set("rate_unit", limiter.RateUnit)
+			d.Set("delay", limiter.Delay)
+		}
+		// resources whose limiter was changed outside of the profile are reapplied on the next apply
+		if *result.Options.RequestLimiter == *limiter {
+			resourceIDs = append(resourceIDs, resourceID)
+		}
+	}
+	if found == 0 || limiter == nil {
+		log.Printf("[WARN] Removing CDN Rate Limiter Profile %s because none of its CDN resources has the limiter", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("resource_ids", resourceIDs)
+
+	log.Println("[DEBUG] Finish CDN Rate Limiter Profile reading")
+	return nil
+}
+
+func resourceCDNRateLimiterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start CDN Rate Limiter Profile updating (id=%s)\n", d.Id())
+	config := m.(*Config)
+
+	config.CDNMutex.Lock()
+	defer config.CDNMutex.Unlock()
+
+	oldIDs, newIDs := d.GetChange("resource_ids")
+	for _, resourceID := range oldIDs.(*schema.Set).Difference(newIDs.(*schema.Set)).List() {
+		if err := setCDNResourceRequestLimiter(ctx, config, int64(resourceID.(int)), nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	limiter := cdnRateLimiterProfileLimiter(d)
+	for _, resourceID := range newIDs.(*schema.Set).List() {
+		if err := setCDNResourceRequestLimiter(ctx, config, int64(resourceID.(int)), limiter); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish CDN Rate Limiter Profile updating")
+	return resourceCDNRateLimiterProfileRead(ctx, d, m)
+}
+
+func resourceCDNRateLimiterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start CDN Rate Limiter Profile deleting (id=%s)\n", d.Id())
+	config := m.(*Config)
+
+	config.CDNMutex.Lock()
+	defer config.CDNMutex.Unlock()
+
+	for _, resourceID := range d.Get("resource_ids").(*schema.Set).List() {
+		if err := setCDNResourceRequestLimiter(ctx, config, int64(resourceID.(int)), nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish CDN Rate Limiter Profile deleting")
+	return nil
+}
+
+func cdnRateLimiterProfileLimiter(d *schema.ResourceData) *gcdn.RequestLimiter {
+	return &gcdn.RequestLimiter{
+		Enabled:  d.Get("enabled").(bool),
+		Rate:     d.Get("rate").(int),
+		Burst:    d.Get("burst").(int),
+		RateUnit: d.Get("rate_unit").(string),
+		Delay:    d.Get("delay").(int),
+	}
+}
+
+// setCDNResourceRequestLimiter replaces the request_limiter option of the CDN resource keeping the rest of its settings.
+// The limiter is disabled when nil is passed.
+func setCDNResourceRequestLimiter(ctx context.Context, config *Config, resourceID int64, limiter *gcdn.RequestLimiter) error {
+	client := config.CDNClient
+
+	result, err := client.Resources().Get(ctx, resourceID)
+	if err != nil {
+		return fmt.Errorf("get cdn resource %d: %w", resourceID, err)
+	}
+
+	options := result.Options
+	if options == nil {
+		options = &gcdn.Options{}
+	}
+	if limiter == nil {
+		if options.RequestLimiter == nil {
+			return nil
+		}
+		disabled := *options.RequestLimiter
+		disabled.Enabled = false
+		limiter = &disabled
+	}
+	options.RequestLimiter = limiter
+
+	var req resources.UpdateRequest
+	req.Active = result.Active
+	req.Description = result.Description
+	req.OriginGroup = int(result.OriginGroup)
+	req.SSlEnabled = result.SSlEnabled
+	req.SSLData = int(result.SSLData)
+	req.OriginProtocol = result.OriginProtocol
+	req.Options = options
+	req.SecondaryHostnames = append(make([]string, 0), result.SecondaryHostnames...)
+	req.ProxySSLEnabled = result.ProxySSLEnabled
+	if result.ProxySSLCA != 0 {
+		req.ProxySSLCA = &result.ProxySSLCA
+	}
+	if result.ProxySSLData != 0 {
+		req.ProxySSLData = &result.ProxySSLData
+	}
+
+	if _, err := client.Resources().Update(ctx, resourceID, &req); err != nil {
+		return fmt.Errorf("update cdn resource %d: %w", resourceID, err)
+	}
+
+	return nil
+}
+
+func parseCDNRateLimiterProfileID(profileID string) ([]interface{}, error) {
+	ids := make([]interface{}, 0)
+	for _, part := range strings.Split(profileID, ",") {
+		resourceID, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("import id must be a comma separated list of CDN resource IDs, got %q", profileID)
+		}
+		ids = append(ids, resourceID)
+	}
+	return ids, nil
+}
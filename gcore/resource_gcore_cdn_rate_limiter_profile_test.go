@@ -0,0 +1,66 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCDNRateLimiterProfile(t *testing.T) {
+	fullName := "gcore_cdn_rate_limiter_profile.acctest"
+
+	template := func(rate int) string {
+		return fmt.Sprintf(`
+resource "gcore_cdn_rate_limiter_profile" "acctest" {
+  name = "acctest"
+  rate = %d
+  burst = 10
+  resource_ids = [%s]
+}
+		`, rate, GCORE_CDN_RESOURCE_ID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckVars(t, GCORE_USERNAME_VAR, GCORE_PASSWORD_VAR, GCORE_CDN_URL_VAR, GCORE_CDN_RESOURCE_ID_VAR)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: template(5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "rate", "5"),
+					resource.TestCheckResourceAttr(fullName, "resource_ids.#", "1"),
+				),
+			},
+			{
+				Config: template(7),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "rate", "7"),
+					resource.TestCheckResourceAttr(fullName, "resource_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseCDNRateLimiterProfileID(t *testing.T) {
+	got, err := parseCDNRateLimiterProfileID("123, 456")
+	if err != nil {
+		t.Fatalf("parseCDNRateLimiterProfileID() error = %v", err)
+	}
+	if want := []interface{}{123, 456}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCDNRateLimiterProfileID() = %v, want %v", got, want)
+	}
+
+	if _, err := parseCDNRateLimiterProfileID("f3c1ab2e-profile"); err == nil {
+		t.Error("parseCDNRateLimiterProfileID() expected error for non numeric id")
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 
 	gcdn "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/origingroups"
 	"github.com/G-Core/gcorelabscdn-go/resources"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -65,6 +69,12 @@ func resourceCDNResource() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "List of additional CNAMEs.",
 			},
+			"secondary_hostnames_dns_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify on plan that every added secondary hostname already has a CNAME record in DNS pointing to the resource cname or to the CDN edge hostname the cname points to. Any CDN edge hostname (*.gcdn.co) is accepted while the cname is unknown or doesn't resolve yet. For a wildcard hostname (eg. *.example.com) a name under its domain is resolved. Fails the plan with the list of hostnames that do not point to the resource instead of a rejection from the API on apply.",
+			},
 			"ssl_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -87,6 +97,11 @@ func resourceCDNResource() *schema.Resource {
 				Computed:    true,
 				Description: "Status of a CDN resource content availability. Possible values are: Active, Suspended, Processed.",
 			},
+			"shield_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if origin shielding is enabled for the CDN resource. Origin shielding is billed separately.",
+			},
 			"primary_resource": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -111,6 +126,27 @@ func resourceCDNResource() *schema.Resource {
 				Description:  "Specify the ID of the SSL certificate used to verify an origin.",
 			},
 			"options": resourceOptionsSchema,
+			"rule":    cdnInlineRuleSchema,
+		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+			if err := validateCDNInlineRules(diff); err != nil {
+				return err
+			}
+			if diff.Get("secondary_hostnames_dns_check").(bool) && diff.HasChange("secondary_hostnames") {
+				o, n := diff.GetChange("secondary_hostnames")
+				added := n.(*schema.Set).Difference(o.(*schema.Set)).List()
+				hostnames := make([]string, 0, len(added))
+				for _, hostname := range added {
+					hostnames = append(hostnames, hostname.(string))
+				}
+				// cname is unknown when it comes from another resource created in the same apply
+				cname := ""
+				if diff.NewValueKnown("cname") {
+					cname = diff.Get("cname").(string)
+				}
+				return checkCDNSecondaryHostnamesDNS(ctx, net.DefaultResolver, cname, hostnames)
+			}
+			return nil
 		},
 		CreateContext: resourceCDNResourceCreate,
 		ReadContext:   resourceCDNResourceRead,
@@ -129,7 +165,11 @@ func resourceCDNResourceCreate(ctx context.Context, d *schema.ResourceData, m in
 	req.Cname = d.Get("cname").(string)
 	req.Description = d.Get("description").(string)
 	req.Origin = d.Get("origin").(string)
-	req.OriginGroup = d.Get("origin_group").(int)
+	originGroup, err := checkCDNOriginGroup(ctx, client.OriginGroups(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req.OriginGroup = originGroup
 	req.OriginProtocol = resources.Protocol(d.Get("origin_protocol").(string))
 	req.SSlEnabled = d.Get("ssl_enabled").(bool)
 	req.SSLData = d.Get("ssl_data").(int)
@@ -150,12 +190,40 @@ func resourceCDNResourceCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	d.SetId(fmt.Sprintf("%d", result.ID))
+
+	if inlineRules := d.Get("rule").([]interface{}); len(inlineRules) > 0 {
+		config.CDNMutex.Lock()
+		created, err := syncCDNInlineRules(ctx, client.Rules(), result.ID, nil, inlineRules)
+		config.CDNMutex.Unlock()
+		if created != nil {
+			if err := d.Set("rule", created); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	resourceCDNResourceRead(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish CDN Resource creating (id=%d)\n", result.ID)
 	return nil
 }
 
+// checkCDNOriginGroup returns ID of the origin group set by origin_group.
+// The group is looked up with the client credentials, so groups of other clients are rejected.
+func checkCDNOriginGroup(ctx context.Context, client origingroups.OriginGroupService, d *schema.ResourceData) (int, error) {
+	id := d.Get("origin_group").(int)
+	if id == 0 || !(d.IsNewResource() || d.HasChange("origin_group")) {
+		return id, nil
+	}
+	if _, err := client.Get(ctx, int64(id)); err != nil {
+		return 0, fmt.Errorf("origin group %d is not available for the client: %w", id, err)
+	}
+	return id, nil
+}
+
 func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resourceID := d.Id()
 	log.Printf("[DEBUG] Start CDN Resource reading (id=%s)\n", resourceID)
@@ -181,6 +249,7 @@ func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("ssl_data", result.SSLData)
 	d.Set("status", result.Status)
 	d.Set("active", result.Active)
+	d.Set("shield_enabled", result.Shielded)
 	d.Set("primary_resource", result.PrimaryResource)
 	d.Set("proxy_ssl_enabled", result.ProxySSLEnabled)
 	d.Set("proxy_ssl_ca", result.ProxySSLCA)
@@ -189,6 +258,16 @@ func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
+	if inlineRules := d.Get("rule").([]interface{}); len(inlineRules) > 0 {
+		data, err := readCDNInlineRules(ctx, client.Rules(), id, inlineRules)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("rule", data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Println("[DEBUG] Finish CDN Resource reading")
 	return nil
 }
@@ -207,7 +286,10 @@ func resourceCDNResourceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	var req resources.UpdateRequest
 	req.Active = d.Get("active").(bool)
 	req.Description = d.Get("description").(string)
-	req.OriginGroup = d.Get("origin_group").(int)
+	req.OriginGroup, err = checkCDNOriginGroup(ctx, client.OriginGroups(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	req.SSlEnabled = d.Get("ssl_enabled").(bool)
 	req.SSLData = d.Get("ssl_data").(int)
 	req.OriginProtocol = resources.Protocol(d.Get("origin_protocol").(string))
@@ -237,6 +319,21 @@ func resourceCDNResourceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("rule") {
+		o, n := d.GetChange("rule")
+		config.CDNMutex.Lock()
+		synced, err := syncCDNInlineRules(ctx, client.Rules(), id, o.([]interface{}), n.([]interface{}))
+		config.CDNMutex.Unlock()
+		if synced != nil {
+			if err := d.Set("rule", synced); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Println("[DEBUG] Finish CDN Resource updating")
 	return resourceCDNResourceRead(ctx, d, m)
 }
@@ -879,3 +976,65 @@ func structToMap(item interface{}) map[string]interface{} {
 	}
 	return res
 }
+
+const (
+	// cdnWildcardProbeLabel is resolved in place of "*" to check wildcard secondary hostnames
+	cdnWildcardProbeLabel = "gcore-cdn-dns-check"
+	// cdnEdgeDomain is the domain of the CDN edge hostnames the resource cname points to
+	cdnEdgeDomain = "gcdn.co"
+)
+
+type cnameResolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// checkCDNSecondaryHostnamesDNS returns an error listing hostnames without a CNAME record pointing to the CDN
+// resource. The record may point to the resource cname or to the CDN edge hostname the cname points to. Any edge
+// hostname is accepted when the cname is unknown or doesn't resolve yet.
+func checkCDNSecondaryHostnamesDNS(ctx context.Context, resolver cnameResolver, cname string, hostnames []string) error {
+	normalize := func(host string) string {
+		return strings.TrimSuffix(strings.ToLower(host), ".")
+	}
+
+	targets := map[string]bool{}
+	edgeKnown := false
+	if cname = normalize(cname); cname != "" {
+		targets[cname] = true
+		if edge, err := resolver.LookupCNAME(ctx, cname); err == nil && normalize(edge) != cname {
+			targets[normalize(edge)] = true
+			edgeKnown = true
+		}
+	}
+
+	var missing []string
+	for _, hostname := range hostnames {
+		host := normalize(hostname)
+		if strings.HasPrefix(host, "*.") {
+			host = cdnWildcardProbeLabel + host[1:]
+		}
+
+		target, err := resolver.LookupCNAME(ctx, host)
+		switch {
+		case err != nil:
+			missing = append(missing, fmt.Sprintf("%s (%s)", hostname, err))
+			continue
+		// resolver returns the host itself when only A/AAAA records exist
+		case normalize(target) == host:
+			missing = append(missing, fmt.Sprintf("%s (no CNAME record)", hostname))
+			continue
+		}
+		target = normalize(target)
+		if targets[target] || (!edgeKnown && strings.HasSuffix(target, "."+cdnEdgeDomain)) {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s (CNAME to %s)", hostname, target))
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	return fmt.Errorf("secondary hostnames without CNAME record pointing to the CDN resource: %s; "+
+		"create CNAME records pointing to the CDN resource cname or disable secondary_hostnames_dns_check",
+		strings.Join(missing, ", "))
+}
@@ -4,10 +4,16 @@
 package gcore
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	gcdn "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/rules"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -60,3 +66,186 @@ resource "gcore_cdn_resource" "acctest" {
 		},
 	})
 }
+
+type fakeCNAMEResolver map[string]string
+
+func (r fakeCNAMEResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	if cname, ok := r[host]; ok {
+		return cname, nil
+	}
+	return "", errors.New("no such host")
+}
+
+func TestCheckCDNSecondaryHostnamesDNS(t *testing.T) {
+	resolver := fakeCNAMEResolver{
+		"cdn.example.com":                     "cl-abc.gcdn.co.",
+		"static.example.com":                  "cl-abc.gcdn.co.",
+		"gcore-cdn-dns-check.img.example.com": "cl-abc.gcdn.co.",
+		"other.example.com":                   "cl-xyz.gcdn.co.",
+		"foreign.example.com":                 "example.cloudfront.net.",
+		"www.example.com":                     "www.example.com.",
+	}
+	tests := []struct {
+		name      string
+		cname     string
+		hostnames []string
+		wantErr   bool
+	}{
+		{
+			name:      "points to the edge of the cname",
+			cname:     "cdn.example.com",
+			hostnames: []string{"static.example.com", "STATIC.example.com."},
+		},
+		{
+			name:      "wildcard",
+			cname:     "cdn.example.com",
+			hostnames: []string{"*.img.example.com"},
+		},
+		{
+			name:      "points to another CDN resource",
+			cname:     "cdn.example.com",
+			hostnames: []string{"other.example.com"},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown cname accepts any edge",
+			hostnames: []string{"static.example.com", "other.example.com"},
+		},
+		{
+			name:      "cname not resolved yet accepts any edge",
+			cname:     "new.example.com",
+			hostnames: []string{"other.example.com"},
+		},
+		{
+			name:      "points outside of the CDN",
+			hostnames: []string{"foreign.example.com"},
+			wantErr:   true,
+		},
+		{
+			name:      "address record only",
+			cname:     "cdn.example.com",
+			hostnames: []string{"www.example.com"},
+			wantErr:   true,
+		},
+		{
+			name:      "not resolved",
+			cname:     "cdn.example.com",
+			hostnames: []string{"static.example.com", "*.example.org"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCDNSecondaryHostnamesDNS(context.Background(), resolver, tt.cname, tt.hostnames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkCDNSecondaryHostnamesDNS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := checkCDNSecondaryHostnamesDNS(context.Background(), resolver, "cdn.example.com", []string{"*.example.org", "other.example.com"})
+	want := "secondary hostnames without CNAME record pointing to the CDN resource: " +
+		"*.example.org (no such host), other.example.com (CNAME to cl-xyz.gcdn.co)"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("checkCDNSecondaryHostnamesDNS() error = %v, want prefix %q", err, want)
+	}
+}
+
+// fakeCDNRules keeps rules of a single CDN resource in memory
+type fakeCDNRules struct {
+	rules  map[int64]rules.Rule
+	nextID int64
+}
+
+func (f *fakeCDNRules) Create(ctx context.Context, resourceID int64, req *rules.CreateRequest) (*rules.Rule, error) {
+	f.nextID++
+	rule := rules.Rule{ID: f.nextID, Name: req.Name, Active: req.Active, Pattern: req.Rule, Type: req.RuleType, Weight: req.Weight, OriginGroup: req.OriginGroup}
+	f.rules[rule.ID] = rule
+	return &rule, nil
+}
+
+func (f *fakeCDNRules) Get(ctx context.Context, resourceID, ruleID int64) (*rules.Rule, error) {
+	rule, ok := f.rules[ruleID]
+	if !ok {
+		return nil, &gcdn.ErrorResponse{StatusCode: http.StatusNotFound}
+	}
+	return &rule, nil
+}
+
+func (f *fakeCDNRules) Update(ctx context.Context, resourceID, ruleID int64, req *rules.UpdateRequest) (*rules.Rule, error) {
+	rule := f.rules[ruleID]
+	rule.Name, rule.Pattern, rule.Weight = req.Name, req.Rule, req.Weight
+	f.rules[ruleID] = rule
+	return &rule, nil
+}
+
+func (f *fakeCDNRules) Delete(ctx context.Context, resourceID, ruleID int64) error {
+	delete(f.rules, ruleID)
+	return nil
+}
+
+func TestReadCDNInlineRules(t *testing.T) {
+	originGroup := 7
+	client := &fakeCDNRules{rules: map[int64]rules.Rule{
+		1: {ID: 1, Name: "images", Active: true, Pattern: "/images/", OriginGroup: &originGroup, Weight: 1},
+		2: {ID: 2, Name: "managed by gcore_cdn_rule", Active: true, Pattern: "/api/"},
+		3: {ID: 3, Name: "static", Pattern: "/static/", Weight: 2},
+	}}
+	current := []interface{}{
+		map[string]interface{}{"id": 3, "name": "static"},
+		map[string]interface{}{"id": 1, "name": "images"},
+		map[string]interface{}{"id": 4, "name": "deleted"},
+	}
+
+	got, err := readCDNInlineRules(context.Background(), client, 10, current)
+	if err != nil {
+		t.Fatalf("readCDNInlineRules() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("readCDNInlineRules() returned %d rules, want 2", len(got))
+	}
+	for i, want := range []struct {
+		id          int
+		name        string
+		originGroup int
+	}{
+		{id: 3, name: "static"},
+		{id: 1, name: "images", originGroup: 7},
+	} {
+		rule := got[i].(map[string]interface{})
+		if rule["id"] != want.id || rule["name"] != want.name || rule["origin_group"] != want.originGroup {
+			t.Errorf("rule %d = %v, want id %d, name %s, origin group %d", i, rule, want.id, want.name, want.originGroup)
+		}
+	}
+}
+
+func TestSyncCDNInlineRules(t *testing.T) {
+	client := &fakeCDNRules{rules: map[int64]rules.Rule{
+		1: {ID: 1, Name: "images", Pattern: "/images/"},
+		2: {ID: 2, Name: "static", Pattern: "/static/"},
+	}, nextID: 2}
+	inlineRule := func(id int, name, pattern string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "active": true, "rule": pattern, "rule_type": 0,
+			"origin_group": 0, "origin_protocol": "", "weight": 0, "options": []interface{}{},
+		}
+	}
+	old := []interface{}{inlineRule(1, "images", "/images/"), inlineRule(2, "static", "/static/")}
+	new := []interface{}{inlineRule(0, "images", "/img/"), inlineRule(0, "fonts", "/fonts/"), inlineRule(0, "video", "/video/")}
+
+	synced, err := syncCDNInlineRules(context.Background(), client, 10, old, new)
+	if err != nil {
+		t.Fatalf("syncCDNInlineRules() error = %v", err)
+	}
+	if _, ok := client.rules[2]; ok {
+		t.Errorf("removed rule static was not deleted")
+	}
+	if client.rules[1].Pattern != "/img/" {
+		t.Errorf("changed rule images was not updated: %v", client.rules[1])
+	}
+	for i, wantID := range []int{1, 3, 4} {
+		if id := synced[i].(map[string]interface{})["id"]; id != wantID {
+			t.Errorf("rule %d id = %v, want %d", i, id, wantID)
+		}
+	}
+}
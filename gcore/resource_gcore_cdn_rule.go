@@ -60,9 +60,28 @@ func resourceCDNRule() *schema.Resource {
 				Description: "The setting allows to enable or disable a Rule. If not specified, it will be enabled.",
 			},
 			"rule": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A pattern that defines when the rule is triggered. By default, we add a leading forward slash to any rule pattern. Specify a pattern without a forward slash.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"rule", "patterns"},
+				Description:  "A pattern that defines when the rule is triggered. By default, we add a leading forward slash to any rule pattern. Specify a pattern without a forward slash. Built from `patterns` if they are used.",
+			},
+			"patterns": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"rule", "patterns"},
+				Description:  "Patterns that define when the rule is triggered, an alternative to `rule`. The rule is triggered if any of the patterns matches. Each pattern is a RegEx starting with '^/' or '/'. Can be used with rule type 0 only.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"negate": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"patterns"},
+				Description:  "Trigger the rule if none of the `patterns` matches.",
 			},
 			"rule_type": {
 				Type:        schema.TypeInt,
@@ -87,6 +106,7 @@ func resourceCDNRule() *schema.Resource {
 			},
 			"options": ruleOptionsSchema,
 		},
+		CustomizeDiff: resourceCDNRulePatternsDiff,
 		CreateContext: resourceCDNRuleCreate,
 		ReadContext:   resourceCDNRuleRead,
 		UpdateContext: resourceCDNRuleUpdate,
@@ -95,15 +115,65 @@ func resourceCDNRule() *schema.Resource {
 	}
 }
 
+func resourceCDNRulePatternsDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	patterns := diff.Get("patterns").([]interface{})
+	if len(patterns) == 0 || !diff.NewValueKnown("patterns") {
+		return nil
+	}
+	if diff.Get("rule_type").(int) != 0 {
+		return fmt.Errorf("patterns can be used with rule type 0 only")
+	}
+	pattern, err := cdnRulePattern(patterns, diff.Get("negate").(bool))
+	if err != nil {
+		return err
+	}
+	if diff.Get("rule").(string) != pattern {
+		return diff.SetNew("rule", pattern)
+	}
+	return nil
+}
+
+func resourceCDNRulePattern(d *schema.ResourceData) (string, error) {
+	if patterns := d.Get("patterns").([]interface{}); len(patterns) > 0 {
+		return cdnRulePattern(patterns, d.Get("negate").(bool))
+	}
+	return d.Get("rule").(string), nil
+}
+
+// cdnRulePattern builds a single RegEx of rule type 0 matching any of the patterns, or none of them if negate is set
+func cdnRulePattern(patterns []interface{}, negate bool) (string, error) {
+	alternatives := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		pattern, _ := p.(string)
+		path := strings.TrimPrefix(pattern, "^")
+		if !strings.HasPrefix(path, "/") {
+			return "", fmt.Errorf("pattern %q must start with '^/' or '/'", pattern)
+		}
+		alternatives = append(alternatives, strings.TrimPrefix(path, "/"))
+	}
+
+	if negate {
+		return fmt.Sprintf("^/(?!(?:%s))", strings.Join(alternatives, "|")), nil
+	}
+	if len(alternatives) == 1 {
+		return "^/" + alternatives[0], nil
+	}
+	return fmt.Sprintf("^/(?:%s)", strings.Join(alternatives, "|")), nil
+}
+
 func resourceCDNRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start CDN Rule creating")
 	config := m.(*Config)
 	client := config.CDNClient
 
+	var err error
 	var req rules.CreateRequest
 	req.Name = d.Get("name").(string)
 	req.Active = d.Get("active").(bool)
-	req.Rule = d.Get("rule").(string)
+	req.Rule, err = resourceCDNRulePattern(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	req.RuleType = d.Get("rule_type").(int)
 
 	if d.Get("weight") != nil {
@@ -122,7 +192,9 @@ func resourceCDNRuleCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	req.Options = listToOptions(d.Get("options").([]interface{}))
 
+	config.CDNMutex.Lock()
 	result, err := client.Rules().Create(ctx, int64(resourceID), &req)
+	config.CDNMutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -155,6 +227,13 @@ func resourceCDNRuleRead(ctx context.Context, d *schema.ResourceData, m interfac
 	d.Set("name", result.Name)
 	d.Set("active", result.Active)
 	d.Set("rule", result.Pattern)
+	// patterns are kept while the rule built from them is unchanged, otherwise they are reapplied
+	if patterns := d.Get("patterns").([]interface{}); len(patterns) > 0 {
+		pattern, err := cdnRulePattern(patterns, d.Get("negate").(bool))
+		if err != nil || pattern != result.Pattern {
+			d.Set("patterns", nil)
+		}
+	}
 	d.Set("rule_type", result.Type)
 	d.Set("origin_group", result.OriginGroup)
 	d.Set("origin_protocol", result.OverrideOriginProtocol)
@@ -181,7 +260,10 @@ func resourceCDNRuleUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	var req rules.UpdateRequest
 	req.Name = d.Get("name").(string)
 	req.Active = d.Get("active").(bool)
-	req.Rule = d.Get("rule").(string)
+	req.Rule, err = resourceCDNRulePattern(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	req.RuleType = d.Get("rule_type").(int)
 
 	if d.Get("weight") != nil {
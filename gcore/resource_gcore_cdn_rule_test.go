@@ -80,3 +80,29 @@ resource "gcore_cdn_rule" "acctest" {
 		},
 	})
 }
+
+func TestCDNRulePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []interface{}
+		negate   bool
+		want     string
+		wantErr  bool
+	}{
+		{name: "single", patterns: []interface{}{"/images/.*\\.png"}, want: "^/images/.*\\.png"},
+		{name: "multiple", patterns: []interface{}{"^/images/", "/scripts/.*\\.js"}, want: "^/(?:images/|scripts/.*\\.js)"},
+		{name: "negate", patterns: []interface{}{"/api/", "/admin/"}, negate: true, want: "^/(?!(?:api/|admin/))"},
+		{name: "no leading slash", patterns: []interface{}{"images/"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cdnRulePattern(tt.patterns, tt.negate)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cdnRulePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cdnRulePattern() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
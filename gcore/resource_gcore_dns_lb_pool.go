@@ -0,0 +1,439 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	DNSLBPoolResource = "gcore_dns_lb_pool"
+
+	DNSLBPoolSchemaZone        = "zone"
+	DNSLBPoolSchemaDomain      = "domain"
+	DNSLBPoolSchemaType        = "type"
+	DNSLBPoolSchemaTTL         = "ttl"
+	DNSLBPoolSchemaAnswers     = "answers"
+	DNSLBPoolSchemaMember      = "member"
+	DNSLBPoolSchemaContent     = "content"
+	DNSLBPoolSchemaWeight      = "weight"
+	DNSLBPoolSchemaBackup      = "backup"
+	DNSLBPoolSchemaEnabled     = "enabled"
+	DNSLBPoolSchemaHealthcheck = "healthcheck"
+
+	dnsFilterIsHealthy       = "is_healthy"
+	dnsFilterWeightedShuffle = "weighted_shuffle"
+	dnsFilterFirstN          = "first_n"
+)
+
+func resourceDNSLBPool() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			DNSLBPoolSchemaZone: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "A zone of the DNS load balancing pool.",
+			},
+			DNSLBPoolSchemaDomain: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "A domain answered by the DNS load balancing pool.",
+			},
+			DNSLBPoolSchemaType: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "A",
+				ValidateFunc: validation.StringInSlice([]string{"A", "AAAA", "CNAME"}, false),
+				Description:  "A type of the pool records. Available values are 'A', 'AAAA', 'CNAME'.",
+			},
+			DNSLBPoolSchemaTTL: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "A ttl of the pool records. Keep it low to let resolvers follow failover quickly.",
+			},
+			DNSLBPoolSchemaAnswers: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of healthy members returned in a single answer.",
+			},
+			DNSLBPoolSchemaMember: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Members of the pool. Healthy primary members are shuffled by weight, backup members are served only when no primary member is healthy.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						DNSLBPoolSchemaContent: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Record content of the member, e.g. IP address.",
+						},
+						DNSLBPoolSchemaWeight: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Relative weight of the member.",
+						},
+						DNSLBPoolSchemaBackup: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Serve the member only as failover when no primary member is healthy.",
+						},
+						DNSLBPoolSchemaEnabled: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Manage of public appearing of the member.",
+						},
+					},
+				},
+			},
+			DNSLBPoolSchemaHealthcheck: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Healthcheck of the pool members.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						DNSZoneRRSetSchemaMetaFailoverProtocol: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"HTTP", "TCP", "UDP", "ICMP"}, false),
+							Description:  "Protocol, possible value: HTTP, TCP, UDP, ICMP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverPort: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "Port to check (1-65535).",
+						},
+						DNSZoneRRSetSchemaMetaFailoverFrequency: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntBetween(10, 3600),
+							Description:  "Frequency in seconds (10-3600).",
+						},
+						DNSZoneRRSetSchemaMetaFailoverTimeout: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntBetween(1, 10),
+							Description:  "Timeout in seconds (1-10).",
+						},
+						DNSZoneRRSetSchemaMetaFailoverMethod: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "HTTP Method required if protocol=HTTP, must be empty for non-HTTP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverURL: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "URL path to check required if protocol=HTTP, must be empty for non-HTTP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverHost: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Request host/virtualhost to send if protocol=HTTP, must be empty for non-HTTP",
+						},
+						DNSZoneRRSetSchemaMetaFailoverHTTPStatusCode: {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Expected status code if protocol=HTTP, must be empty for non-HTTP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverTLS: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "TLS/HTTPS enabled if protocol=HTTP, must be empty for non-HTTP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverRegexp: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "HTTP body or response payload to check if protocol<>ICMP, must be empty for ICMP.",
+						},
+						DNSZoneRRSetSchemaMetaFailoverCommand: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 255),
+							Description:  "Command to send if protocol=TCP/UDP, maximum length: 255.",
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CreateContext: checkDNSDependency(resourceDNSLBPoolCreate),
+		UpdateContext: checkDNSDependency(resourceDNSLBPoolUpdate),
+		ReadContext:   checkDNSDependency(resourceDNSLBPoolRead),
+		DeleteContext: checkDNSDependency(resourceDNSLBPoolDelete),
+		Description: "Represent DNS load balancing pool: weighted records with healthchecks and failover to backup members, " +
+			"compiled into a single RRSet with filters and meta.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				zone, domain, rType, err := parseDNSLBPoolID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				_ = d.Set(DNSLBPoolSchemaZone, zone)
+				_ = d.Set(DNSLBPoolSchemaDomain, domain)
+				_ = d.Set(DNSLBPoolSchemaType, rType)
+				_ = d.Set(DNSLBPoolSchemaAnswers, 1)
+				d.SetId(fmt.Sprintf("%s:%s:%s", zone, domain, rType))
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+func resourceDNSLBPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSLBPoolSchemaZone).(string))
+	domain := strings.TrimSpace(d.Get(DNSLBPoolSchemaDomain).(string))
+	rType := d.Get(DNSLBPoolSchemaType).(string)
+	log.Println("[DEBUG] Start DNS LB Pool Resource creating")
+	defer log.Printf("[DEBUG] Finish DNS LB Pool Resource creating (id=%s %s %s)\n", zone, domain, rType)
+
+	rrSet, err := dnsLBPoolRRSet(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	if err := client.CreateRRSet(ctx, zone, domain, rType, rrSet); err != nil {
+		return diag.FromErr(fmt.Errorf("create zone rrset: %v", err))
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", zone, domain, rType))
+
+	return resourceDNSLBPoolRead(ctx, d, m)
+}
+
+func resourceDNSLBPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSLBPoolSchemaZone).(string))
+	domain := strings.TrimSpace(d.Get(DNSLBPoolSchemaDomain).(string))
+	rType := d.Get(DNSLBPoolSchemaType).(string)
+	log.Println("[DEBUG] Start DNS LB Pool Resource updating")
+	defer log.Printf("[DEBUG] Finish DNS LB Pool Resource updating (id=%s %s %s)\n", zone, domain, rType)
+
+	rrSet, err := dnsLBPoolRRSet(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	if err := client.UpdateRRSet(ctx, zone, domain, rType, rrSet); err != nil {
+		return diag.FromErr(fmt.Errorf("update zone rrset: %v", err))
+	}
+
+	return resourceDNSLBPoolRead(ctx, d, m)
+}
+
+func resourceDNSLBPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSLBPoolSchemaZone).(string))
+	domain := strings.TrimSpace(d.Get(DNSLBPoolSchemaDomain).(string))
+	rType := d.Get(DNSLBPoolSchemaType).(string)
+	log.Println("[DEBUG] Start DNS LB Pool Resource reading")
+	defer log.Printf("[DEBUG] Finish DNS LB Pool Resource reading (id=%s %s %s)\n", zone, domain, rType)
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	result, err := client.RRSet(ctx, zone, domain, rType)
+	if err != nil {
+		var apiErr dnssdk.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN] DNS LB Pool %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("get zone rrset: %w", err))
+	}
+
+	_ = d.Set(DNSLBPoolSchemaTTL, result.TTL)
+	for _, f := range result.Filters {
+		if f.Type == dnsFilterFirstN {
+			_ = d.Set(DNSLBPoolSchemaAnswers, int(f.Limit))
+		}
+	}
+
+	members := dnsLBPoolMembers(result.Records, d.Get(DNSLBPoolSchemaMember).([]interface{}))
+	if err := d.Set(DNSLBPoolSchemaMember, members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hc, ok := result.Meta[DNSZoneRRSetSchemaMetaHealthchecks].(map[string]any); ok {
+		if err := d.Set(DNSLBPoolSchemaHealthcheck, []interface{}{dnsLBPoolHealthcheck(hc)}); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if hc, ok := result.Meta[DNSZoneRRSetSchemaMetaFailover].(map[string]any); ok {
+		if err := d.Set(DNSLBPoolSchemaHealthcheck, []interface{}{dnsLBPoolHealthcheck(hc)}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDNSLBPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSLBPoolSchemaZone).(string))
+	domain := strings.TrimSpace(d.Get(DNSLBPoolSchemaDomain).(string))
+	rType := d.Get(DNSLBPoolSchemaType).(string)
+	log.Println("[DEBUG] Start DNS LB Pool Resource deleting")
+	defer log.Printf("[DEBUG] Finish DNS LB Pool Resource deleting (id=%s %s %s)\n", zone, domain, rType)
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	if err := client.DeleteRRSet(ctx, zone, domain, rType); err != nil {
+		return diag.FromErr(fmt.Errorf("delete zone rrset: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// dnsLBPoolRRSet compiles pool into RRSet: unhealthy members are dropped, the rest is shuffled by weight
+// and the first answers records are returned. Backup members are kept by is_healthy only when no primary is healthy.
+func dnsLBPoolRRSet(d *schema.ResourceData) (dnssdk.RRSet, error) {
+	rType := d.Get(DNSLBPoolSchemaType).(string)
+	rrSet := dnssdk.RRSet{TTL: d.Get(DNSLBPoolSchemaTTL).(int), Records: make([]dnssdk.ResourceRecord, 0)}
+	rrSet.AddFilter(
+		dnssdk.RecordFilter{Type: dnsFilterIsHealthy, Strict: false},
+		dnssdk.RecordFilter{Type: dnsFilterWeightedShuffle, Strict: false},
+		dnssdk.NewFirstNFilter(uint(d.Get(DNSLBPoolSchemaAnswers).(int)), false),
+	)
+
+	healthcheck := map[string]any{}
+	for _, raw := range d.Get(DNSLBPoolSchemaHealthcheck).([]interface{}) {
+		for k, v := range raw.(map[string]interface{}) {
+			// skip unset optional fields, API rejects them for protocols they don't belong to
+			switch val := v.(type) {
+			case string:
+				if val == "" {
+					continue
+				}
+			case int:
+				if val == 0 {
+					continue
+				}
+			case bool:
+				if !val {
+					continue
+				}
+			}
+			healthcheck[k] = v
+		}
+	}
+	rrSet.Meta = map[string]any{
+		DNSZoneRRSetSchemaMetaHealthchecks: healthcheck,
+		DNSZoneRRSetSchemaMetaFailover:     healthcheck,
+	}
+
+	for _, raw := range d.Get(DNSLBPoolSchemaMember).([]interface{}) {
+		member := raw.(map[string]interface{})
+		rr := (&dnssdk.ResourceRecord{}).SetContent(rType, member[DNSLBPoolSchemaContent].(string))
+		rr.Enabled = member[DNSLBPoolSchemaEnabled].(bool)
+		rr.AddMeta(dnssdk.NewResourceMetaWeight(member[DNSLBPoolSchemaWeight].(int)))
+		if member[DNSLBPoolSchemaBackup].(bool) {
+			rr.AddMeta(dnssdk.NewResourceMetaBackup())
+		}
+		rrSet.Records = append(rrSet.Records, *rr)
+	}
+
+	return rrSet, nil
+}
+
+// dnsLBPoolMembers converts records into members, keeping order of the members in state
+func dnsLBPoolMembers(records []dnssdk.ResourceRecord, current []interface{}) []interface{} {
+	byContent := make(map[string]map[string]interface{}, len(records))
+	var order []string
+	for _, rec := range records {
+		weight, _ := toInt(rec.Meta[DNSLBPoolSchemaWeight])
+		backup, _ := rec.Meta[DNSLBPoolSchemaBackup].(bool)
+		content := rec.ContentToString()
+		byContent[content] = map[string]interface{}{
+			DNSLBPoolSchemaContent: content,
+			DNSLBPoolSchemaWeight:  weight,
+			DNSLBPoolSchemaBackup:  backup,
+			DNSLBPoolSchemaEnabled: rec.Enabled,
+		}
+		order = append(order, content)
+	}
+
+	members := make([]interface{}, 0, len(records))
+	for _, raw := range current {
+		content := raw.(map[string]interface{})[DNSLBPoolSchemaContent].(string)
+		if member, ok := byContent[content]; ok {
+			members = append(members, member)
+			delete(byContent, content)
+		}
+	}
+	for _, content := range order {
+		if member, ok := byContent[content]; ok {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func dnsLBPoolHealthcheck(meta map[string]any) map[string]interface{} {
+	hc := map[string]interface{}{}
+	for _, k := range []string{
+		DNSZoneRRSetSchemaMetaFailoverProtocol,
+		DNSZoneRRSetSchemaMetaFailoverMethod,
+		DNSZoneRRSetSchemaMetaFailoverURL,
+		DNSZoneRRSetSchemaMetaFailoverHost,
+		DNSZoneRRSetSchemaMetaFailoverRegexp,
+		DNSZoneRRSetSchemaMetaFailoverCommand,
+	} {
+		if v, dtv := toString(meta[k]); dtv == dtvString {
+			hc[k] = v
+		}
+	}
+	for _, k := range []string{
+		DNSZoneRRSetSchemaMetaFailoverPort,
+		DNSZoneRRSetSchemaMetaFailoverFrequency,
+		DNSZoneRRSetSchemaMetaFailoverTimeout,
+		DNSZoneRRSetSchemaMetaFailoverHTTPStatusCode,
+	} {
+		if v, dtv := toInt(meta[k]); dtv == dtvInt {
+			hc[k] = v
+		}
+	}
+	if v, ok := meta[DNSZoneRRSetSchemaMetaFailoverTLS].(bool); ok {
+		hc[DNSZoneRRSetSchemaMetaFailoverTLS] = v
+	}
+	return hc
+}
+
+// parseDNSLBPoolID splits the zone:domain:type ID of the pool.
+func parseDNSLBPoolID(id string) (string, string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("format must be as zone:domain:type, got %q", id)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), strings.ToUpper(strings.TrimSpace(parts[2])), nil
+}
@@ -0,0 +1,120 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"reflect"
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDNSLBPoolRRSet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDNSLBPool().Schema, map[string]interface{}{
+		DNSLBPoolSchemaZone:    "example.com",
+		DNSLBPoolSchemaDomain:  "www.example.com",
+		DNSLBPoolSchemaAnswers: 2,
+		DNSLBPoolSchemaMember: []interface{}{
+			map[string]interface{}{DNSLBPoolSchemaContent: "192.0.2.1", DNSLBPoolSchemaWeight: 3},
+			map[string]interface{}{DNSLBPoolSchemaContent: "192.0.2.2"},
+			map[string]interface{}{DNSLBPoolSchemaContent: "192.0.2.3", DNSLBPoolSchemaBackup: true},
+		},
+		DNSLBPoolSchemaHealthcheck: []interface{}{
+			map[string]interface{}{
+				DNSZoneRRSetSchemaMetaFailoverProtocol: "TCP",
+				DNSZoneRRSetSchemaMetaFailoverPort:     443,
+			},
+		},
+	})
+
+	rrSet, err := dnsLBPoolRRSet(d)
+	if err != nil {
+		t.Fatalf("dnsLBPoolRRSet() error = %v", err)
+	}
+
+	wantFilters := []dnssdk.RecordFilter{
+		{Type: dnsFilterIsHealthy},
+		{Type: dnsFilterWeightedShuffle},
+		{Type: dnsFilterFirstN, Limit: 2},
+	}
+	if !reflect.DeepEqual(rrSet.Filters, wantFilters) {
+		t.Errorf("filters = %v, want %v", rrSet.Filters, wantFilters)
+	}
+
+	wantHC := map[string]any{
+		DNSZoneRRSetSchemaMetaFailoverProtocol:  "TCP",
+		DNSZoneRRSetSchemaMetaFailoverPort:      443,
+		DNSZoneRRSetSchemaMetaFailoverFrequency: 60,
+		DNSZoneRRSetSchemaMetaFailoverTimeout:   10,
+	}
+	for _, key := range []string{DNSZoneRRSetSchemaMetaHealthchecks, DNSZoneRRSetSchemaMetaFailover} {
+		if !reflect.DeepEqual(rrSet.Meta[key], wantHC) {
+			t.Errorf("meta %s = %v, want %v", key, rrSet.Meta[key], wantHC)
+		}
+	}
+
+	wantMeta := []map[string]any{
+		{DNSLBPoolSchemaWeight: 3},
+		{DNSLBPoolSchemaWeight: 1},
+		{DNSLBPoolSchemaWeight: 1, DNSLBPoolSchemaBackup: true},
+	}
+	if len(rrSet.Records) != len(wantMeta) {
+		t.Fatalf("records = %d, want %d", len(rrSet.Records), len(wantMeta))
+	}
+	for i, rec := range rrSet.Records {
+		if !rec.Enabled {
+			t.Errorf("record %d is disabled", i)
+		}
+		if !reflect.DeepEqual(rec.Meta, wantMeta[i]) {
+			t.Errorf("record %d meta = %v, want %v", i, rec.Meta, wantMeta[i])
+		}
+	}
+}
+
+func TestDNSLBPoolMembers(t *testing.T) {
+	records := []dnssdk.ResourceRecord{
+		{Content: []any{"192.0.2.1"}, Meta: map[string]any{"weight": float64(2)}, Enabled: true},
+		{Content: []any{"192.0.2.2"}, Meta: map[string]any{"weight": float64(1), "backup": true}, Enabled: true},
+	}
+	current := []interface{}{
+		map[string]interface{}{DNSLBPoolSchemaContent: "192.0.2.2"},
+		map[string]interface{}{DNSLBPoolSchemaContent: "192.0.2.1"},
+	}
+
+	got := dnsLBPoolMembers(records, current)
+	want := []interface{}{
+		map[string]interface{}{
+			DNSLBPoolSchemaContent: "192.0.2.2",
+			DNSLBPoolSchemaWeight:  1,
+			DNSLBPoolSchemaBackup:  true,
+			DNSLBPoolSchemaEnabled: true,
+		},
+		map[string]interface{}{
+			DNSLBPoolSchemaContent: "192.0.2.1",
+			DNSLBPoolSchemaWeight:  2,
+			DNSLBPoolSchemaBackup:  false,
+			DNSLBPoolSchemaEnabled: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dnsLBPoolMembers() = %v, want %v", got, want)
+	}
+}
+
+func TestParseDNSLBPoolID(t *testing.T) {
+	zone, domain, rType, err := parseDNSLBPoolID("example.com:www.example.com:aaaa")
+	if err != nil {
+		t.Fatalf("parseDNSLBPoolID() error = %v", err)
+	}
+	if zone != "example.com" || domain != "www.example.com" || rType != "AAAA" {
+		t.Errorf("parseDNSLBPoolID() = %q, %q, %q", zone, domain, rType)
+	}
+
+	for _, id := range []string{"", "example.com", "example.com:www.example.com", "example.com::A", "a:b:c:d"} {
+		if _, _, _, err := parseDNSLBPoolID(id); err == nil {
+			t.Errorf("parseDNSLBPoolID(%q) expected error", id)
+		}
+	}
+}
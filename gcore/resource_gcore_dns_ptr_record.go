@@ -0,0 +1,353 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/floatingips"
+	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	DNSPTRRecordResource = "gcore_dns_ptr_record"
+
+	DNSPTRRecordSchemaIPAddress       = "ip_address"
+	DNSPTRRecordSchemaFloatingIPID    = "floatingip_id"
+	DNSPTRRecordSchemaReservedFixedIP = "reservedfixedip_port_id"
+	DNSPTRRecordSchemaZone            = "zone"
+	DNSPTRRecordSchemaDomain          = "domain"
+	DNSPTRRecordSchemaPTRDName        = "ptrdname"
+	DNSPTRRecordSchemaTTL             = "ttl"
+
+	dnsPTRRecordType = "PTR"
+)
+
+var dnsPTRRecordIPSources = []string{
+	DNSPTRRecordSchemaIPAddress,
+	DNSPTRRecordSchemaFloatingIPID,
+	DNSPTRRecordSchemaReservedFixedIP,
+}
+
+func resourceDNSPTRRecord() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID of the floating IP or reserved fixed IP. Required only when the address is taken from the cloud.",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID of the floating IP or reserved fixed IP. Required only when the address is taken from the cloud.",
+			},
+			"project_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"region_id"},
+			},
+			DNSPTRRecordSchemaIPAddress: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: dnsPTRRecordIPSources,
+				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+					val := i.(string)
+					if net.ParseIP(val) == nil {
+						return diag.Errorf("%s has wrong format: %s", DNSPTRRecordSchemaIPAddress, val)
+					}
+					return nil
+				},
+				Description: "IPv4 or IPv6 address the reverse record is created for.",
+			},
+			DNSPTRRecordSchemaFloatingIPID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: dnsPTRRecordIPSources,
+				Description:  "ID of the floating IP whose address the reverse record is created for.",
+			},
+			DNSPTRRecordSchemaReservedFixedIP: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: dnsPTRRecordIPSources,
+				Description:  "Port ID of the reserved fixed IP whose address the reverse record is created for.",
+			},
+			DNSPTRRecordSchemaZone: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Reverse zone (eg. 2.0.192.in-addr.arpa) the record belongs to. When omitted, the most specific reverse zone of the account containing the address is used.",
+			},
+			DNSPTRRecordSchemaDomain: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Reverse domain name of the address (eg. 10.2.0.192.in-addr.arpa).",
+			},
+			DNSPTRRecordSchemaPTRDName: {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+					val := i.(string)
+					if strings.TrimSpace(val) == "" || len(val) > 255 {
+						return diag.Errorf("dns ptr record ptrdname can't be empty, it also should be less than 256 symbols")
+					}
+					return nil
+				},
+				Description: "Host name the address resolves to (eg. mail.example.com.).",
+			},
+			DNSPTRRecordSchemaTTL: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+					val := i.(int)
+					if val < 0 {
+						return diag.Errorf("dns ptr record ttl can't be less than 0")
+					}
+					return nil
+				},
+				Description: "A ttl of DNS PTR Record resource.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CreateContext: checkDNSDependency(resourceDNSPTRRecordCreate),
+		UpdateContext: checkDNSDependency(resourceDNSPTRRecordUpdate),
+		ReadContext:   checkDNSDependency(resourceDNSPTRRecordRead),
+		DeleteContext: checkDNSDependency(resourceDNSPTRRecordDelete),
+		Description:   "Represent reverse DNS (PTR) record of an IP address. https://dns.gcore.com/zones",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.Split(d.Id(), ":")
+				if len(parts) < 2 {
+					return nil, fmt.Errorf("format must be as zone:ip_address")
+				}
+				// IPv6 addresses contain colons themselves
+				zone, ipAddress := parts[0], strings.Join(parts[1:], ":")
+				domain, err := reverseDNSName(ipAddress)
+				if err != nil {
+					return nil, err
+				}
+				_ = d.Set(DNSPTRRecordSchemaZone, zone)
+				_ = d.Set(DNSPTRRecordSchemaIPAddress, ipAddress)
+				_ = d.Set(DNSPTRRecordSchemaDomain, domain)
+				d.SetId(domain)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+func resourceDNSPTRRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start DNS PTR Record Resource creating")
+	config := m.(*Config)
+	client := config.DNSClient
+
+	ipAddress, err := resolveDNSPTRRecordIPAddress(d, config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domain, err := reverseDNSName(ipAddress)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zone := strings.TrimSpace(d.Get(DNSPTRRecordSchemaZone).(string))
+	if zone == "" {
+		zones, err := client.AllZones(ctx, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("list zones: %w", err))
+		}
+		zone = findReverseZone(zones, domain)
+		if zone == "" {
+			return diag.Errorf("no reverse zone found for %s, create it with %s resource first", ipAddress, DNSZoneResource)
+		}
+	} else if domain != zone && !strings.HasSuffix(domain, "."+zone) {
+		return diag.Errorf("address %s does not belong to reverse zone %s", ipAddress, zone)
+	}
+
+	err = client.CreateRRSet(ctx, zone, domain, dnsPTRRecordType, dnsPTRRecordRRSet(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("create zone rrset: %v", err))
+	}
+
+	_ = d.Set(DNSPTRRecordSchemaIPAddress, ipAddress)
+	_ = d.Set(DNSPTRRecordSchemaZone, zone)
+	_ = d.Set(DNSPTRRecordSchemaDomain, domain)
+	d.SetId(domain)
+	log.Printf("[DEBUG] Finish DNS PTR Record Resource creating (id=%s)\n", domain)
+
+	return resourceDNSPTRRecordRead(ctx, d, m)
+}
+
+func resourceDNSPTRRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Id() == "" {
+		return diag.Errorf("empty id")
+	}
+	zone := d.Get(DNSPTRRecordSchemaZone).(string)
+	log.Printf("[DEBUG] Start DNS PTR Record Resource updating (id=%s)\n", d.Id())
+	defer log.Printf("[DEBUG] Finish DNS PTR Record Resource updating (id=%s)\n", d.Id())
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	err := client.UpdateRRSet(ctx, zone, d.Id(), dnsPTRRecordType, dnsPTRRecordRRSet(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("update zone rrset: %v", err))
+	}
+
+	return resourceDNSPTRRecordRead(ctx, d, m)
+}
+
+func resourceDNSPTRRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Id() == "" {
+		return diag.Errorf("empty id")
+	}
+	zone := d.Get(DNSPTRRecordSchemaZone).(string)
+	log.Printf("[DEBUG] Start DNS PTR Record Resource reading (id=%s)\n", d.Id())
+	defer log.Printf("[DEBUG] Finish DNS PTR Record Resource reading (id=%s)\n", d.Id())
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	result, err := client.RRSet(ctx, zone, d.Id(), dnsPTRRecordType)
+	if err != nil {
+		var apiErr dnssdk.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN] DNS PTR Record (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("get zone rrset: %w", err))
+	}
+
+	_ = d.Set(DNSPTRRecordSchemaDomain, d.Id())
+	_ = d.Set(DNSPTRRecordSchemaTTL, result.TTL)
+	if len(result.Records) > 0 {
+		_ = d.Set(DNSPTRRecordSchemaPTRDName, result.Records[0].ContentToString())
+	}
+
+	return nil
+}
+
+func resourceDNSPTRRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Id() == "" {
+		return diag.Errorf("empty id")
+	}
+	zone := d.Get(DNSPTRRecordSchemaZone).(string)
+	log.Printf("[DEBUG] Start DNS PTR Record Resource deleting (id=%s)\n", d.Id())
+	defer log.Printf("[DEBUG] Finish DNS PTR Record Resource deleting (id=%s)\n", d.Id())
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	err := client.DeleteRRSet(ctx, zone, d.Id(), dnsPTRRecordType)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("delete zone rrset: %w", err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func dnsPTRRecordRRSet(d *schema.ResourceData) dnssdk.RRSet {
+	rr := (&dnssdk.ResourceRecord{Enabled: true}).SetContent(dnsPTRRecordType, d.Get(DNSPTRRecordSchemaPTRDName).(string))
+	return dnssdk.RRSet{
+		TTL:     d.Get(DNSPTRRecordSchemaTTL).(int),
+		Records: []dnssdk.ResourceRecord{*rr},
+	}
+}
+
+// resolveDNSPTRRecordIPAddress returns the address of the record, looking up the floating IP or reserved fixed IP when needed.
+func resolveDNSPTRRecordIPAddress(d *schema.ResourceData, config *Config) (string, error) {
+	if ipAddress, ok := d.GetOk(DNSPTRRecordSchemaIPAddress); ok {
+		return ipAddress.(string), nil
+	}
+
+	if fipID, ok := d.GetOk(DNSPTRRecordSchemaFloatingIPID); ok {
+		client, err := CreateClient(config.Provider, d, floatingIPsPoint, versionPointV1)
+		if err != nil {
+			return "", err
+		}
+		fip, err := floatingips.Get(client, fipID.(string)).Extract()
+		if err != nil {
+			return "", fmt.Errorf("get floating ip: %w", err)
+		}
+		return fip.FloatingIPAddress.String(), nil
+	}
+
+	portID := d.Get(DNSPTRRecordSchemaReservedFixedIP).(string)
+	client, err := CreateClient(config.Provider, d, reservedFixedIPsPoint, versionPointV1)
+	if err != nil {
+		return "", err
+	}
+	rfip, err := reservedfixedips.Get(client, portID).Extract()
+	if err != nil {
+		return "", fmt.Errorf("get reserved fixed ip: %w", err)
+	}
+	return rfip.FixedIPAddress.String(), nil
+}
+
+// reverseDNSName converts an IP address to its in-addr.arpa or ip6.arpa name.
+func reverseDNSName(ipAddress string) (string, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return "", fmt.Errorf("wrong ip address: %s", ipAddress)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", ip4[3], ip4[2], ip4[1], ip4[0]), nil
+	}
+
+	nibbles := make([]string, 0, len(ip)*2)
+	for i := len(ip) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, strconv.FormatUint(uint64(ip[i]&0x0f), 16), strconv.FormatUint(uint64(ip[i]>>4), 16))
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa", nil
+}
+
+// findReverseZone returns the most specific zone that contains the reverse domain name.
+func findReverseZone(zones []dnssdk.Zone, domain string) string {
+	var found string
+	for _, zone := range zones {
+		name := strings.TrimSuffix(zone.Name, ".")
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+		if len(name) > len(found) {
+			found = name
+		}
+	}
+	return found
+}
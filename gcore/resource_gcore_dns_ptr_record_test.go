@@ -0,0 +1,62 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+)
+
+func TestReverseDNSName(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "ipv4",
+			ip:   "192.0.2.10",
+			want: "10.2.0.192.in-addr.arpa",
+		},
+		{
+			name: "ipv6",
+			ip:   "2001:db8::567:89ab",
+			want: "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{
+			name:    "wrong address",
+			ip:      "192.0.2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reverseDNSName(tt.ip)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reverseDNSName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("reverseDNSName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindReverseZone(t *testing.T) {
+	zones := []dnssdk.Zone{
+		{Name: "example.com"},
+		{Name: "0.192.in-addr.arpa"},
+		{Name: "2.0.192.in-addr.arpa"},
+		{Name: "12.0.192.in-addr.arpa"},
+	}
+	if got := findReverseZone(zones, "10.2.0.192.in-addr.arpa"); got != "2.0.192.in-addr.arpa" {
+		t.Errorf("findReverseZone() got = %v, want %v", got, "2.0.192.in-addr.arpa")
+	}
+	if got := findReverseZone(zones, "10.2.0.10.in-addr.arpa"); got != "" {
+		t.Errorf("findReverseZone() got = %v, want empty", got)
+	}
+}
@@ -0,0 +1,399 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	DNSSwitchoverResource = "gcore_dns_switchover"
+
+	DNSSwitchoverSchemaZone     = "zone"
+	DNSSwitchoverSchemaPosition = "position"
+	DNSSwitchoverSchemaRecord   = "record"
+	DNSSwitchoverSchemaDomain   = "domain"
+	DNSSwitchoverSchemaType     = "type"
+	DNSSwitchoverSchemaTTL      = "ttl"
+	DNSSwitchoverSchemaBlue     = "blue"
+	DNSSwitchoverSchemaGreen    = "green"
+
+	dnsSwitchoverPositionBlue  = "blue"
+	dnsSwitchoverPositionGreen = "green"
+)
+
+// dnsRRSetClient is the part of the DNS client used to switch records over
+type dnsRRSetClient interface {
+	RRSet(ctx context.Context, zone, name, recordType string) (dnssdk.RRSet, error)
+	CreateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error
+	UpdateRRSet(ctx context.Context, zone, name, recordType string, record dnssdk.RRSet) error
+	DeleteRRSet(ctx context.Context, zone, name, recordType string) error
+}
+
+// dnsSwitchoverRecord is a record with its content in both positions
+type dnsSwitchoverRecord struct {
+	Domain string
+	Type   string
+	TTL    int
+	Blue   []string
+	Green  []string
+}
+
+func (r dnsSwitchoverRecord) key() string {
+	return r.Domain + " " + r.Type
+}
+
+func (r dnsSwitchoverRecord) contents(position string) []string {
+	if position == dnsSwitchoverPositionGreen {
+		return r.Green
+	}
+	return r.Blue
+}
+
+func (r dnsSwitchoverRecord) rrSet(position string) dnssdk.RRSet {
+	rrSet := dnssdk.RRSet{TTL: r.TTL, Records: make([]dnssdk.ResourceRecord, 0)}
+	for _, content := range r.contents(position) {
+		rr := (&dnssdk.ResourceRecord{Enabled: true}).SetContent(r.Type, content)
+		rrSet.Records = append(rrSet.Records, *rr)
+	}
+	return rrSet
+}
+
+func resourceDNSSwitchover() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			DNSSwitchoverSchemaZone: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "A zone of the switched records.",
+			},
+			DNSSwitchoverSchemaPosition: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{dnsSwitchoverPositionBlue, dnsSwitchoverPositionGreen}, false),
+				Description: "Active configuration of the records, 'blue' or 'green'. Changing it switches all records over. " +
+					"If switching any record fails, records already switched are restored.",
+			},
+			DNSSwitchoverSchemaRecord: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Records switched together. Each record owns the whole RRSet of its domain and type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						DNSSwitchoverSchemaDomain: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A domain of the record.",
+						},
+						DNSSwitchoverSchemaType: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"A", "AAAA", "MX", "CNAME", "TXT", "CAA", "NS", "SRV", "HTTPS", "SVCB"}, true),
+							Description:  "A type of the record.",
+						},
+						DNSSwitchoverSchemaTTL: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "A ttl of the record. Keep it low to let resolvers follow the switchover quickly.",
+						},
+						DNSSwitchoverSchemaBlue: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Record contents served in the blue position.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						DNSSwitchoverSchemaGreen: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Record contents served in the green position.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CreateContext: checkDNSDependency(resourceDNSSwitchoverCreate),
+		UpdateContext: checkDNSDependency(resourceDNSSwitchoverUpdate),
+		ReadContext:   checkDNSDependency(resourceDNSSwitchoverRead),
+		DeleteContext: checkDNSDependency(resourceDNSSwitchoverDelete),
+		CustomizeDiff: validateDNSSwitchoverRecords,
+		Description: "Represent a set of DNS records switched between two predefined configurations, blue and green, " +
+			"with a single position toggle, eg. for traffic cutovers coordinated with load balancer or CDN changes.",
+	}
+}
+
+func resourceDNSSwitchoverCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSSwitchoverSchemaZone).(string))
+	position := d.Get(DNSSwitchoverSchemaPosition).(string)
+	log.Println("[DEBUG] Start DNS Switchover Resource creating")
+	defer log.Printf("[DEBUG] Finish DNS Switchover Resource creating (zone=%s position=%s)\n", zone, position)
+
+	config := m.(*Config)
+	records := dnsSwitchoverRecords(d.Get(DNSSwitchoverSchemaRecord).([]interface{}))
+	if err := applyDNSSwitchover(ctx, config.DNSClient, zone, position, records, nil); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id.UniqueId())
+
+	return resourceDNSSwitchoverRead(ctx, d, m)
+}
+
+func resourceDNSSwitchoverUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSSwitchoverSchemaZone).(string))
+	position := d.Get(DNSSwitchoverSchemaPosition).(string)
+	log.Println("[DEBUG] Start DNS Switchover Resource updating")
+	defer log.Printf("[DEBUG] Finish DNS Switchover Resource updating (zone=%s position=%s)\n", zone, position)
+
+	config := m.(*Config)
+	oldRaw, newRaw := d.GetChange(DNSSwitchoverSchemaRecord)
+	records := dnsSwitchoverRecords(newRaw.([]interface{}))
+	kept := make(map[string]bool, len(records))
+	for _, r := range records {
+		kept[r.key()] = true
+	}
+	var removed []dnsSwitchoverRecord
+	for _, r := range dnsSwitchoverRecords(oldRaw.([]interface{})) {
+		if !kept[r.key()] {
+			removed = append(removed, r)
+		}
+	}
+
+	if err := applyDNSSwitchover(ctx, config.DNSClient, zone, position, records, removed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDNSSwitchoverRead(ctx, d, m)
+}
+
+func resourceDNSSwitchoverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSSwitchoverSchemaZone).(string))
+	log.Println("[DEBUG] Start DNS Switchover Resource reading")
+	defer log.Printf("[DEBUG] Finish DNS Switchover Resource reading (zone=%s)\n", zone)
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	rawRecords := d.Get(DNSSwitchoverSchemaRecord).([]interface{})
+	records := dnsSwitchoverRecords(rawRecords)
+	served := make(map[string][]string, len(records))
+	for i, r := range records {
+		result, err := client.RRSet(ctx, zone, r.Domain, r.Type)
+		if err != nil {
+			if isDNSNotFound(err) {
+				continue
+			}
+			return diag.FromErr(fmt.Errorf("get zone rrset %s: %w", r.key(), err))
+		}
+		contents := make([]string, 0, len(result.Records))
+		for _, rec := range result.Records {
+			contents = append(contents, rec.ContentToString())
+		}
+		served[r.key()] = contents
+		rawRecords[i].(map[string]interface{})[DNSSwitchoverSchemaTTL] = result.TTL
+	}
+	if len(served) == 0 {
+		log.Printf("[WARN] DNS Switchover (%s) records not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(DNSSwitchoverSchemaRecord, rawRecords); err != nil {
+		return diag.FromErr(err)
+	}
+	// records changed outside of terraform leave the position empty, so the next apply switches them again
+	_ = d.Set(DNSSwitchoverSchemaPosition, dnsSwitchoverPosition(records, served, d.Get(DNSSwitchoverSchemaPosition).(string)))
+
+	return nil
+}
+
+func resourceDNSSwitchoverDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := strings.TrimSpace(d.Get(DNSSwitchoverSchemaZone).(string))
+	log.Println("[DEBUG] Start DNS Switchover Resource deleting")
+	defer log.Printf("[DEBUG] Finish DNS Switchover Resource deleting (zone=%s)\n", zone)
+
+	config := m.(*Config)
+	client := config.DNSClient
+
+	for _, r := range dnsSwitchoverRecords(d.Get(DNSSwitchoverSchemaRecord).([]interface{})) {
+		if err := client.DeleteRRSet(ctx, zone, r.Domain, r.Type); err != nil && !isDNSNotFound(err) {
+			return diag.FromErr(fmt.Errorf("delete zone rrset %s: %w", r.key(), err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// validateDNSSwitchoverRecords checks that every RRSet is switched by a single record
+func validateDNSSwitchoverRecords(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	keys := make(map[string]bool)
+	for _, r := range dnsSwitchoverRecords(diff.Get(DNSSwitchoverSchemaRecord).([]interface{})) {
+		if keys[r.key()] {
+			return fmt.Errorf("record %s is defined more than once", r.key())
+		}
+		keys[r.key()] = true
+	}
+	return nil
+}
+
+func dnsSwitchoverRecords(raw []interface{}) []dnsSwitchoverRecord {
+	records := make([]dnsSwitchoverRecord, 0, len(raw))
+	for _, v := range raw {
+		if v == nil {
+			continue
+		}
+		rec := v.(map[string]interface{})
+		r := dnsSwitchoverRecord{
+			Domain: strings.TrimSpace(rec[DNSSwitchoverSchemaDomain].(string)),
+			Type:   strings.ToUpper(rec[DNSSwitchoverSchemaType].(string)),
+			TTL:    rec[DNSSwitchoverSchemaTTL].(int),
+		}
+		for _, content := range rec[DNSSwitchoverSchemaBlue].([]interface{}) {
+			r.Blue = append(r.Blue, content.(string))
+		}
+		for _, content := range rec[DNSSwitchoverSchemaGreen].([]interface{}) {
+			r.Green = append(r.Green, content.(string))
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// applyDNSSwitchover serves contents of the position for all records and deletes removed ones. The API has no
+// transactions, so RRSets are saved first and if any change fails, the changes already made are reverted.
+func applyDNSSwitchover(ctx context.Context, client dnsRRSetClient, zone, position string, records, removed []dnsSwitchoverRecord) error {
+	type snapshot struct {
+		record  dnsSwitchoverRecord
+		rrSet   dnssdk.RRSet
+		existed bool
+	}
+	snapshots := make([]snapshot, 0, len(records)+len(removed))
+	for _, r := range append(append([]dnsSwitchoverRecord{}, records...), removed...) {
+		rrSet, err := client.RRSet(ctx, zone, r.Domain, r.Type)
+		if err != nil && !isDNSNotFound(err) {
+			return fmt.Errorf("get zone rrset %s: %w", r.key(), err)
+		}
+		snapshots = append(snapshots, snapshot{record: r, rrSet: rrSet, existed: err == nil})
+	}
+
+	var applyErr error
+	applied := 0
+	for i, s := range snapshots {
+		r := s.record
+		switch {
+		case i >= len(records):
+			if s.existed {
+				log.Printf("[DEBUG] Delete switchover record %s", r.key())
+				applyErr = client.DeleteRRSet(ctx, zone, r.Domain, r.Type)
+			}
+		case s.existed:
+			log.Printf("[DEBUG] Switch record %s to %s", r.key(), position)
+			applyErr = client.UpdateRRSet(ctx, zone, r.Domain, r.Type, r.rrSet(position))
+		default:
+			log.Printf("[DEBUG] Create record %s in %s", r.key(), position)
+			applyErr = client.CreateRRSet(ctx, zone, r.Domain, r.Type, r.rrSet(position))
+		}
+		if applyErr != nil {
+			applyErr = fmt.Errorf("switch zone rrset %s to %s: %w", r.key(), position, applyErr)
+			break
+		}
+		applied++
+	}
+	if applyErr == nil {
+		return nil
+	}
+
+	var rollbackErrs []string
+	for i := applied - 1; i >= 0; i-- {
+		s := snapshots[i]
+		r := s.record
+		log.Printf("[DEBUG] Revert record %s", r.key())
+		var err error
+		switch {
+		case !s.existed:
+			if i < len(records) {
+				err = client.DeleteRRSet(ctx, zone, r.Domain, r.Type)
+			}
+		case i >= len(records):
+			err = client.CreateRRSet(ctx, zone, r.Domain, r.Type, s.rrSet)
+		default:
+			err = client.UpdateRRSet(ctx, zone, r.Domain, r.Type, s.rrSet)
+		}
+		if err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Sprintf("%s: %s", r.key(), err))
+		}
+	}
+	if len(rollbackErrs) > 0 {
+		return fmt.Errorf("%w; reverting switched records failed, records may be served partially: %s", applyErr, strings.Join(rollbackErrs, "; "))
+	}
+	return fmt.Errorf("%w; switched records were reverted", applyErr)
+}
+
+// dnsSwitchoverPosition returns the position served by all records, the current one is preferred when records are
+// the same in both positions. Empty position is returned if records don't match any position.
+func dnsSwitchoverPosition(records []dnsSwitchoverRecord, served map[string][]string, current string) string {
+	candidates := []string{dnsSwitchoverPositionBlue, dnsSwitchoverPositionGreen}
+	if current == dnsSwitchoverPositionGreen {
+		candidates = []string{dnsSwitchoverPositionGreen, dnsSwitchoverPositionBlue}
+	}
+	for _, position := range candidates {
+		matched := true
+		for _, r := range records {
+			contents, ok := served[r.key()]
+			if !ok || !dnsSwitchoverContentsEqual(r.Type, r.contents(position), contents) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return position
+		}
+	}
+	return ""
+}
+
+// dnsSwitchoverContentsEqual compares configured contents with the served ones regardless of order and formatting
+func dnsSwitchoverContentsEqual(rType string, configured, served []string) bool {
+	if len(configured) != len(served) {
+		return false
+	}
+	normalized := make([]string, len(configured))
+	for i, content := range configured {
+		normalized[i] = (&dnssdk.ResourceRecord{}).SetContent(rType, content).ContentToString()
+	}
+	sortedServed := append([]string{}, served...)
+	sort.Strings(normalized)
+	sort.Strings(sortedServed)
+	for i := range normalized {
+		if normalized[i] != sortedServed[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isDNSNotFound(err error) bool {
+	var apiErr dnssdk.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
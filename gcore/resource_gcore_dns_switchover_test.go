@@ -0,0 +1,127 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+)
+
+type fakeDNSRRSetClient struct {
+	rrSets map[string]dnssdk.RRSet
+	failOn string
+}
+
+func (c *fakeDNSRRSetClient) RRSet(_ context.Context, _, name, recordType string) (dnssdk.RRSet, error) {
+	rrSet, ok := c.rrSets[name+" "+recordType]
+	if !ok {
+		return dnssdk.RRSet{}, dnssdk.APIError{StatusCode: http.StatusNotFound}
+	}
+	return rrSet, nil
+}
+
+func (c *fakeDNSRRSetClient) CreateRRSet(_ context.Context, _, name, recordType string, record dnssdk.RRSet) error {
+	return c.set(name+" "+recordType, record)
+}
+
+func (c *fakeDNSRRSetClient) UpdateRRSet(_ context.Context, _, name, recordType string, record dnssdk.RRSet) error {
+	return c.set(name+" "+recordType, record)
+}
+
+func (c *fakeDNSRRSetClient) DeleteRRSet(_ context.Context, _, name, recordType string) error {
+	delete(c.rrSets, name+" "+recordType)
+	return nil
+}
+
+func (c *fakeDNSRRSetClient) set(key string, record dnssdk.RRSet) error {
+	if key == c.failOn {
+		return fmt.Errorf("rrset %s rejected", key)
+	}
+	c.rrSets[key] = record
+	return nil
+}
+
+func (c *fakeDNSRRSetClient) served() map[string][]string {
+	result := make(map[string][]string, len(c.rrSets))
+	for key, rrSet := range c.rrSets {
+		for _, rec := range rrSet.Records {
+			result[key] = append(result[key], rec.ContentToString())
+		}
+	}
+	return result
+}
+
+func TestApplyDNSSwitchover(t *testing.T) {
+	ctx := context.Background()
+	records := []dnsSwitchoverRecord{
+		{Domain: "www.example.com", Type: "A", TTL: 60, Blue: []string{"192.0.2.1"}, Green: []string{"192.0.2.2", "192.0.2.3"}},
+		{Domain: "api.example.com", Type: "CNAME", TTL: 60, Blue: []string{"blue.example.net."}, Green: []string{"green.example.net."}},
+	}
+	client := &fakeDNSRRSetClient{rrSets: map[string]dnssdk.RRSet{}}
+
+	if err := applyDNSSwitchover(ctx, client, "example.com", dnsSwitchoverPositionBlue, records, nil); err != nil {
+		t.Fatalf("applyDNSSwitchover() to blue error = %v", err)
+	}
+	if got := dnsSwitchoverPosition(records, client.served(), ""); got != dnsSwitchoverPositionBlue {
+		t.Errorf("position after switch to blue = %q", got)
+	}
+
+	if err := applyDNSSwitchover(ctx, client, "example.com", dnsSwitchoverPositionGreen, records, nil); err != nil {
+		t.Fatalf("applyDNSSwitchover() to green error = %v", err)
+	}
+	if got := dnsSwitchoverPosition(records, client.served(), dnsSwitchoverPositionBlue); got != dnsSwitchoverPositionGreen {
+		t.Errorf("position after switch to green = %q", got)
+	}
+
+	// the second record fails, the first one is switched back
+	client.failOn = "api.example.com CNAME"
+	if err := applyDNSSwitchover(ctx, client, "example.com", dnsSwitchoverPositionBlue, records, nil); err == nil {
+		t.Fatal("applyDNSSwitchover() with failing record got no error")
+	}
+	if got := dnsSwitchoverPosition(records, client.served(), dnsSwitchoverPositionGreen); got != dnsSwitchoverPositionGreen {
+		t.Errorf("position after failed switch = %q, want records reverted to green", got)
+	}
+
+	// removed record is deleted
+	client.failOn = ""
+	if err := applyDNSSwitchover(ctx, client, "example.com", dnsSwitchoverPositionGreen, records[:1], records[1:]); err != nil {
+		t.Fatalf("applyDNSSwitchover() removing record error = %v", err)
+	}
+	if _, ok := client.rrSets["api.example.com CNAME"]; ok {
+		t.Error("removed record was not deleted")
+	}
+}
+
+func TestDNSSwitchoverPosition(t *testing.T) {
+	records := []dnsSwitchoverRecord{
+		{Domain: "www.example.com", Type: "A", Blue: []string{"192.0.2.1"}, Green: []string{"192.0.2.2"}},
+		{Domain: "static.example.com", Type: "A", Blue: []string{"192.0.2.9"}, Green: []string{"192.0.2.9"}},
+	}
+	tests := []struct {
+		name    string
+		served  map[string][]string
+		current string
+		want    string
+	}{
+		{"blue", map[string][]string{"www.example.com A": {"192.0.2.1"}, "static.example.com A": {"192.0.2.9"}}, "green", "blue"},
+		{"green", map[string][]string{"www.example.com A": {"192.0.2.2"}, "static.example.com A": {"192.0.2.9"}}, "blue", "green"},
+		{"changed outside", map[string][]string{"www.example.com A": {"192.0.2.5"}, "static.example.com A": {"192.0.2.9"}}, "blue", ""},
+		{"missing record", map[string][]string{"www.example.com A": {"192.0.2.1"}}, "blue", ""},
+	}
+	for _, tt := range tests {
+		if got := dnsSwitchoverPosition(records, tt.served, tt.current); got != tt.want {
+			t.Errorf("%s: dnsSwitchoverPosition() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	same := records[1:]
+	served := map[string][]string{"static.example.com A": {"192.0.2.9"}}
+	if got := dnsSwitchoverPosition(same, served, dnsSwitchoverPositionGreen); got != dnsSwitchoverPositionGreen {
+		t.Errorf("records equal in both positions: dnsSwitchoverPosition() = %q, want current position", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -414,6 +415,7 @@ func resourceDNSZoneRecord() *schema.Resource {
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: validateDNSZoneRecordContents,
 		CreateContext: checkDNSDependency(resourceDNSZoneRecordCreate),
 		UpdateContext: checkDNSDependency(resourceDNSZoneRecordUpdate),
 		ReadContext:   checkDNSDependency(resourceDNSZoneRecordRead),
@@ -791,3 +793,118 @@ func fillRRSet(d *schema.ResourceData, rType string, rrSet *dnssdk.RRSet) error
 	}
 	return nil
 }
+
+// dnsTXTStringMaxLength is the limit of a single character-string of TXT record content
+const dnsTXTStringMaxLength = 255
+
+var dnsCAATags = []string{"issue", "issuewild", "iodef", "issuemail", "issuevmc"}
+
+// validateDNSZoneRecordContents checks resource record contents against the record type at plan time,
+// so malformed records don't fail partway through apply.
+func validateDNSZoneRecordContents(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	rType := strings.TrimSpace(diff.Get(DNSZoneRecordSchemaType).(string))
+	for _, resource := range diff.Get(DNSZoneRecordSchemaResourceRecord).(*schema.Set).List() {
+		content := resource.(map[string]interface{})[DNSZoneRecordSchemaContent].(string)
+		// unknown at plan time
+		if content == "" {
+			continue
+		}
+		if err := validateDNSRecordContent(rType, content); err != nil {
+			return fmt.Errorf("invalid %s record content %q: %w", strings.ToUpper(rType), content, err)
+		}
+	}
+	return nil
+}
+
+func validateDNSRecordContent(rType, content string) error {
+	fields := strings.Fields(content)
+	switch strings.ToUpper(rType) {
+	case "MX":
+		if len(fields) != 2 {
+			return fmt.Errorf("expected '<priority> <exchange>', eg. '10 mail.example.com.'")
+		}
+		if err := validateDNSUint(fields[0], "priority", 65535); err != nil {
+			return err
+		}
+	case "SRV":
+		if len(fields) != 4 {
+			return fmt.Errorf("expected '<priority> <weight> <port> <target>', eg. '10 5 5060 sip.example.com.'")
+		}
+		for i, name := range []string{"priority", "weight", "port"} {
+			if err := validateDNSUint(fields[i], name, 65535); err != nil {
+				return err
+			}
+		}
+	case "CAA":
+		if len(fields) < 3 {
+			return fmt.Errorf(`expected '<flags> <tag> <value>', eg. '0 issue "letsencrypt.org"'`)
+		}
+		if err := validateDNSUint(fields[0], "flags", 255); err != nil {
+			return err
+		}
+		tag := strings.ToLower(fields[1])
+		found := false
+		for _, t := range dnsCAATags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("tag %s should be one of %v", fields[1], dnsCAATags)
+		}
+	case "TXT":
+		for _, str := range dnsTXTStrings(content) {
+			if len(str) > dnsTXTStringMaxLength {
+				return fmt.Errorf("TXT string is %d characters long, split it into quoted strings of at most %d characters, eg. '\"part1\" \"part2\"'",
+					len(str), dnsTXTStringMaxLength)
+			}
+		}
+	}
+	return nil
+}
+
+func validateDNSUint(val, name string, max uint64) error {
+	v, err := strconv.ParseUint(val, 10, 64)
+	if err != nil || v > max {
+		return fmt.Errorf("%s should be an integer between 0 and %d, got: %s", name, max, val)
+	}
+	return nil
+}
+
+// dnsTXTStrings splits TXT record content into its character-strings: quoted parts when the content is quoted,
+// otherwise the whole content.
+func dnsTXTStrings(content string) []string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return []string{content}
+	}
+
+	var (
+		strs    []string
+		current strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range content {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			if quoted {
+				strs = append(strs, current.String())
+				current.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		strs = append(strs, current.String())
+	}
+	return strs
+}
@@ -354,3 +354,36 @@ resource "%s" "%s" {
 		},
 	})
 }
+
+func TestValidateDNSRecordContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		rType   string
+		content string
+		wantErr bool
+	}{
+		{name: "mx", rType: "MX", content: "10 mail.example.com."},
+		{name: "mx without priority", rType: "MX", content: "mail.example.com.", wantErr: true},
+		{name: "mx wrong priority", rType: "mx", content: "-1 mail.example.com.", wantErr: true},
+		{name: "srv", rType: "SRV", content: "10 5 5060 sip.example.com."},
+		{name: "srv missing target", rType: "SRV", content: "10 5 5060", wantErr: true},
+		{name: "srv wrong port", rType: "SRV", content: "10 5 65536 sip.example.com.", wantErr: true},
+		{name: "caa", rType: "CAA", content: `0 issue "letsencrypt.org"`},
+		{name: "caa unknown tag", rType: "CAA", content: `0 issues "letsencrypt.org"`, wantErr: true},
+		{name: "caa wrong flags", rType: "CAA", content: `256 issue "letsencrypt.org"`, wantErr: true},
+		{name: "txt", rType: "TXT", content: "v=spf1 -all"},
+		{name: "txt too long", rType: "TXT", content: strings.Repeat("a", 256), wantErr: true},
+		{name: "txt split", rType: "TXT", content: fmt.Sprintf(`"%s" "%s"`, strings.Repeat("a", 255), strings.Repeat("b", 10))},
+		{name: "txt split part too long", rType: "TXT", content: fmt.Sprintf(`"%s" "b"`, strings.Repeat("a", 256)), wantErr: true},
+		{name: "other types are not checked", rType: "A", content: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDNSRecordContent(tt.rType, tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDNSRecordContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
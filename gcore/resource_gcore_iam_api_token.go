@@ -0,0 +1,238 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type iamAPITokenClientUser struct {
+	Role iamRole `json:"role"`
+}
+
+type iamCreateAPITokenOpts struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	ExpDate     *string               `json:"exp_date"`
+	ClientUser  iamAPITokenClientUser `json:"client_user"`
+}
+
+type iamAPIToken struct {
+	ID          int                   `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	ExpDate     *string               `json:"exp_date"`
+	Expired     bool                  `json:"expired"`
+	Deleted     bool                  `json:"deleted"`
+	Created     string                `json:"created"`
+	LastUsage   *string               `json:"last_usage"`
+	ClientUser  iamAPITokenClientUser `json:"client_user"`
+}
+
+func resourceIAMAPIToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIAMAPITokenCreate,
+		ReadContext:   resourceIAMAPITokenRead,
+		DeleteContext: resourceIAMAPITokenDelete,
+		Description:   "Represent a permanent API token of the account. The token can't be changed, any change creates a new token.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Token name, unique within the account.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Token description.",
+			},
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Expiration time of the token in RFC3339 format, eg. 2025-01-01T00:00:00Z. The token never expires if omitted.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(iamRoles, false),
+				Description:  fmt.Sprintf("Account role the token is scoped to, one of: %s.", strings.Join(iamRoles, ", ")),
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "API token value, available only after creation.",
+			},
+			"expired": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the token is expired.",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time of the token.",
+			},
+			"last_usage": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last time the token was used.",
+			},
+		},
+	}
+}
+
+func resourceIAMAPITokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start IAM API token creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	role, err := getIAMRole(provider, config.PlatformAPI, d.Get("role").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := iamCreateAPITokenOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ClientUser:  iamAPITokenClientUser{Role: role},
+	}
+	if expiresAt := d.Get("expires_at").(string); expiresAt != "" {
+		exp, _ := time.Parse(time.RFC3339, expiresAt)
+		if !exp.After(time.Now()) {
+			return diag.Errorf("expires_at %s is in the past", expiresAt)
+		}
+		opts.ExpDate = &expiresAt
+	}
+
+	tokensURL := iamURL(config.PlatformAPI, "clients", strconv.Itoa(clientID), "tokens")
+	var result struct {
+		Token string `json:"token"`
+	}
+	if _, err := provider.Request(http.MethodPost, tokensURL, &gcorecloud.RequestOpts{
+		JSONBody:     opts,
+		JSONResponse: &result,
+		OkCodes:      []int{http.StatusOK, http.StatusCreated},
+	}); err != nil {
+		return diag.Errorf("cannot create API token %s: %s", opts.Name, err)
+	}
+
+	// the token ID is not returned on creation, token names are unique within the account
+	var tokens []iamAPIToken
+	if _, err := provider.Request(http.MethodGet, tokensURL, &gcorecloud.RequestOpts{
+		JSONResponse: &tokens,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	for _, t := range tokens {
+		if t.Name == opts.Name && !t.Deleted {
+			d.SetId(strconv.Itoa(t.ID))
+			break
+		}
+	}
+	if d.Id() == "" {
+		return diag.Errorf("API token %s not found after creation", opts.Name)
+	}
+	d.Set("token", result.Token)
+
+	log.Printf("[DEBUG] Finish IAM API token creating (%s)", d.Id())
+	return resourceIAMAPITokenRead(ctx, d, m)
+}
+
+func resourceIAMAPITokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start IAM API token reading (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var token iamAPIToken
+	if _, err := provider.Request(http.MethodGet, iamURL(config.PlatformAPI, "clients", strconv.Itoa(clientID), "tokens", d.Id()), &gcorecloud.RequestOpts{
+		JSONResponse: &token,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			log.Printf("[WARN] Removing IAM API token %s because resource doesn't exist anymore", d.Id())
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+	if token.Deleted {
+		log.Printf("[WARN] Removing IAM API token %s because it was deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", token.Name)
+	d.Set("description", token.Description)
+	d.Set("role", token.ClientUser.Role.Name)
+	d.Set("expired", token.Expired)
+	d.Set("created", token.Created)
+	if token.LastUsage != nil {
+		d.Set("last_usage", *token.LastUsage)
+	}
+	// keep the configured format of the expiration time if it is the same moment
+	if token.ExpDate != nil {
+		exp, err := time.Parse(time.RFC3339, *token.ExpDate)
+		current, _ := time.Parse(time.RFC3339, d.Get("expires_at").(string))
+		if err != nil || !exp.Equal(current) {
+			d.Set("expires_at", *token.ExpDate)
+		}
+	} else {
+		d.Set("expires_at", "")
+	}
+
+	log.Println("[DEBUG] Finish IAM API token reading")
+	return nil
+}
+
+func resourceIAMAPITokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start IAM API token deleting (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := provider.Request(http.MethodDelete, iamURL(config.PlatformAPI, "clients", strconv.Itoa(clientID), "tokens", d.Id()), &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusNoContent},
+	}); err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+		default:
+			return diag.Errorf("cannot delete API token %s: %s", d.Id(), err)
+		}
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish IAM API token deleting")
+	return nil
+}
@@ -0,0 +1,459 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const userAssignmentsPoint = "users/assignments"
+
+// iamRoles are names of the account roles (IAM groups), their IDs are looked up through the API
+var iamRoles = []string{"Administrators", "Engineers", "Purge and Prefetch only (API)", "Purge and Prefetch only (API+Web)", "Users"}
+
+var cloudProjectRoles = []string{"ClientAdministrator", "InternalNetworkOnlyUser", "Observer", "ProjectAdministrator", "User"}
+
+type iamRole struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type iamInviteUserOpts struct {
+	ClientID int     `json:"client_id"`
+	Email    string  `json:"email"`
+	Name     string  `json:"name,omitempty"`
+	Lang     string  `json:"lang,omitempty"`
+	UserRole iamRole `json:"user_role"`
+}
+
+type iamUpdateUserOpts struct {
+	Name   string    `json:"name,omitempty"`
+	Lang   string    `json:"lang,omitempty"`
+	Groups []iamRole `json:"groups"`
+}
+
+type iamUser struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Lang      string    `json:"lang"`
+	Groups    []iamRole `json:"groups"`
+	Activated bool      `json:"activated"`
+	Deleted   bool      `json:"deleted"`
+}
+
+type userAssignment struct {
+	ID        int    `json:"id,omitempty"`
+	Role      string `json:"role"`
+	UserID    int    `json:"user_id"`
+	ClientID  int    `json:"client_id"`
+	ProjectID *int   `json:"project_id"`
+}
+
+type userAssignments struct {
+	Count   int              `json:"count"`
+	Results []userAssignment `json:"results"`
+}
+
+func resourceIAMUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIAMUserCreate,
+		ReadContext:   resourceIAMUserRead,
+		UpdateContext: resourceIAMUserUpdate,
+		DeleteContext: resourceIAMUserDelete,
+		Description:   "Represent a user invited to the account, with the account role and roles in cloud projects.",
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Email the invitation is sent to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "User name.",
+			},
+			"lang": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "en",
+				ValidateFunc: validation.StringInSlice([]string{"de", "en", "ru", "zh", "az"}, false),
+				Description:  "Language of the invitation and the user interface.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(iamRoles, false),
+				Description:  fmt.Sprintf("Account role of the user, one of: %s.", strings.Join(iamRoles, ", ")),
+			},
+			"project_role": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Roles of the user in cloud projects.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Cloud project ID. The role is assigned in all projects of the account if omitted.",
+						},
+						"role": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(cloudProjectRoles, false),
+							Description:  fmt.Sprintf("Role in the project, one of: %s.", strings.Join(cloudProjectRoles, ", ")),
+						},
+					},
+				},
+			},
+			"client_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the account the user is invited to.",
+			},
+			"activated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the user accepted the invitation.",
+			},
+		},
+	}
+}
+
+func resourceIAMUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start IAM user creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	role, err := getIAMRole(provider, config.PlatformAPI, d.Get("role").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := iamInviteUserRequest(d, clientID, role)
+	var result struct {
+		UserID int `json:"user_id"`
+	}
+	if _, err := provider.Request(http.MethodPost, iamURL(config.PlatformAPI, "clients", "invite_user"), &gcorecloud.RequestOpts{
+		JSONBody:     opts,
+		JSONResponse: &result,
+		OkCodes:      []int{http.StatusOK, http.StatusCreated},
+	}); err != nil {
+		return diag.Errorf("cannot invite user %s: %s", opts.Email, err)
+	}
+
+	d.SetId(strconv.Itoa(result.UserID))
+
+	for _, raw := range d.Get("project_role").(*schema.Set).List() {
+		if err := assignUserProjectRole(provider, clientID, result.UserID, raw.(map[string]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finish IAM user creating (%s)", d.Id())
+	return resourceIAMUserRead(ctx, d, m)
+}
+
+func resourceIAMUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start IAM user reading (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	var user iamUser
+	if _, err := provider.Request(http.MethodGet, iamURL(config.PlatformAPI, "users", d.Id()), &gcorecloud.RequestOpts{
+		JSONResponse: &user,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			log.Printf("[WARN] Removing IAM user %s because resource doesn't exist anymore", d.Id())
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+	if user.Deleted {
+		log.Printf("[WARN] Removing IAM user %s because it was deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("email", user.Email)
+	d.Set("name", user.Name)
+	if user.Lang != "" {
+		d.Set("lang", user.Lang)
+	}
+	if role := iamUserRole(user.Groups); role != "" {
+		d.Set("role", role)
+	}
+	d.Set("client_id", clientID)
+	d.Set("activated", user.Activated)
+
+	assignments, err := listUserProjectRoles(provider, user.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("project_role", iamUserProjectRoles(assignments)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish IAM user reading")
+	return nil
+}
+
+func resourceIAMUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start IAM user updating (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	userID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "lang", "role") {
+		role, err := getIAMRole(provider, config.PlatformAPI, d.Get("role").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		opts := iamUpdateUserRequest(d, role)
+		if _, err := provider.Request(http.MethodPatch, iamURL(config.PlatformAPI, "users", d.Id()), &gcorecloud.RequestOpts{
+			JSONBody: opts,
+			OkCodes:  []int{http.StatusOK},
+		}); err != nil {
+			return diag.Errorf("cannot update user %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("project_role") {
+		clientID, err := iamClientID(provider, config.PlatformAPI)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		o, n := d.GetChange("project_role")
+		oldRoles, newRoles := o.(*schema.Set), n.(*schema.Set)
+
+		assignments, err := listUserProjectRoles(provider, userID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, raw := range oldRoles.Difference(newRoles).List() {
+			projectRole := raw.(map[string]interface{})
+			for _, a := range assignments {
+				if a.Role == projectRole["role"].(string) && userAssignmentProjectID(a) == projectRole["project_id"].(int) {
+					if err := deleteUserProjectRole(provider, a.ID); err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
+		for _, raw := range newRoles.Difference(oldRoles).List() {
+			if err := assignUserProjectRole(provider, clientID, userID, raw.(map[string]interface{})); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	log.Println("[DEBUG] Finish IAM user updating")
+	return resourceIAMUserRead(ctx, d, m)
+}
+
+func resourceIAMUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start IAM user deleting (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	clientID, err := iamClientID(provider, config.PlatformAPI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := provider.Request(http.MethodDelete, iamURL(config.PlatformAPI, "clients", strconv.Itoa(clientID), "client-users", d.Id()), &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusNoContent},
+	}); err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+		default:
+			return diag.Errorf("cannot remove user %s from the account: %s", d.Id(), err)
+		}
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish IAM user deleting")
+	return nil
+}
+
+func iamURL(platformAPI string, parts ...string) string {
+	return strings.TrimSuffix(platformAPI, "/") + "/" + strings.Join(parts, "/")
+}
+
+// iamClientID returns ID of the account the provider is authenticated to
+func iamClientID(provider *gcorecloud.ProviderClient, platformAPI string) (int, error) {
+	var account clientAccount
+	if _, err := provider.Request(http.MethodGet, iamURL(platformAPI, "clients", "me"), &gcorecloud.RequestOpts{
+		JSONResponse: &account,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		return 0, fmt.Errorf("cannot get account: %w", err)
+	}
+	return account.ID, nil
+}
+
+// getIAMRole looks up the account role (IAM group) by name
+func getIAMRole(provider *gcorecloud.ProviderClient, platformAPI, name string) (iamRole, error) {
+	var groups []iamRole
+	if _, err := provider.Request(http.MethodGet, iamURL(platformAPI, "groups"), &gcorecloud.RequestOpts{
+		JSONResponse: &groups,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		return iamRole{}, fmt.Errorf("cannot get account roles: %w", err)
+	}
+	return findIAMRole(groups, name)
+}
+
+func findIAMRole(groups []iamRole, name string) (iamRole, error) {
+	for _, group := range groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+	return iamRole{}, fmt.Errorf("account role %s not found", name)
+}
+
+// iamUserRole returns the known account role among the user groups
+func iamUserRole(groups []iamRole) string {
+	for _, group := range groups {
+		for _, role := range iamRoles {
+			if group.Name == role {
+				return role
+			}
+		}
+	}
+	return ""
+}
+
+func iamInviteUserRequest(d *schema.ResourceData, clientID int, role iamRole) iamInviteUserOpts {
+	return iamInviteUserOpts{
+		ClientID: clientID,
+		Email:    d.Get("email").(string),
+		Name:     d.Get("name").(string),
+		Lang:     d.Get("lang").(string),
+		UserRole: role,
+	}
+}
+
+func iamUpdateUserRequest(d *schema.ResourceData, role iamRole) iamUpdateUserOpts {
+	return iamUpdateUserOpts{
+		Name:   d.Get("name").(string),
+		Lang:   d.Get("lang").(string),
+		Groups: []iamRole{role},
+	}
+}
+
+func iamUserProjectRoles(assignments []userAssignment) []interface{} {
+	projectRoles := make([]interface{}, 0, len(assignments))
+	for _, a := range assignments {
+		projectRoles = append(projectRoles, map[string]interface{}{
+			"role":       a.Role,
+			"project_id": userAssignmentProjectID(a),
+		})
+	}
+	return projectRoles
+}
+
+func userAssignmentsURL(provider *gcorecloud.ProviderClient) (string, error) {
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    userAssignmentsPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV1,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(client.ResourceBaseURL(), "/"), nil
+}
+
+func userAssignmentProjectID(a userAssignment) int {
+	if a.ProjectID == nil {
+		return 0
+	}
+	return *a.ProjectID
+}
+
+func listUserProjectRoles(provider *gcorecloud.ProviderClient, userID int) ([]userAssignment, error) {
+	url, err := userAssignmentsURL(provider)
+	if err != nil {
+		return nil, err
+	}
+	var assignments userAssignments
+	if _, err := provider.Request(http.MethodGet, fmt.Sprintf("%s?user_id=%d", url, userID), &gcorecloud.RequestOpts{
+		JSONResponse: &assignments,
+		OkCodes:      []int{http.StatusOK},
+	}); err != nil {
+		return nil, fmt.Errorf("cannot get project roles of user %d: %w", userID, err)
+	}
+	return assignments.Results, nil
+}
+
+func assignUserProjectRole(provider *gcorecloud.ProviderClient, clientID, userID int, projectRole map[string]interface{}) error {
+	url, err := userAssignmentsURL(provider)
+	if err != nil {
+		return err
+	}
+	opts := userAssignment{
+		Role:     projectRole["role"].(string),
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	if projectID := projectRole["project_id"].(int); projectID != 0 {
+		opts.ProjectID = &projectID
+	}
+	if _, err := provider.Request(http.MethodPost, url, &gcorecloud.RequestOpts{
+		JSONBody: opts,
+		OkCodes:  []int{http.StatusOK, http.StatusCreated},
+	}); err != nil {
+		return fmt.Errorf("cannot assign role %s to user %d: %w", opts.Role, userID, err)
+	}
+	return nil
+}
+
+func deleteUserProjectRole(provider *gcorecloud.ProviderClient, assignmentID int) error {
+	url, err := userAssignmentsURL(provider)
+	if err != nil {
+		return err
+	}
+	if _, err := provider.Request(http.MethodDelete, fmt.Sprintf("%s/%d", url, assignmentID), &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusNoContent},
+	}); err != nil {
+		return fmt.Errorf("cannot delete role assignment %d: %w", assignmentID, err)
+	}
+	return nil
+}
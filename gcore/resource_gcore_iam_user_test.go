@@ -0,0 +1,99 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFindIAMRole(t *testing.T) {
+	var groups []iamRole
+	if err := json.Unmarshal([]byte(`[{"id":1,"name":"Administrators"},{"id":2009,"name":"Engineers"}]`), &groups); err != nil {
+		t.Fatal(err)
+	}
+
+	role, err := findIAMRole(groups, "Engineers")
+	if err != nil {
+		t.Fatalf("findIAMRole() error = %v", err)
+	}
+	if want := (iamRole{ID: 2009, Name: "Engineers"}); role != want {
+		t.Errorf("findIAMRole() = %v, want %v", role, want)
+	}
+	if _, err := findIAMRole(groups, "Users"); err == nil {
+		t.Errorf("findIAMRole() expected error for missing role")
+	}
+}
+
+func TestIAMUserRole(t *testing.T) {
+	var user iamUser
+	body := `{"id":10,"email":"engineer@example.com","groups":[{"id":42,"name":"Custom"},{"id":2009,"name":"Engineers"}]}`
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := iamUserRole(user.Groups); got != "Engineers" {
+		t.Errorf("iamUserRole() = %q, want %q", got, "Engineers")
+	}
+	if got := iamUserRole([]iamRole{{ID: 42, Name: "Custom"}}); got != "" {
+		t.Errorf("iamUserRole() = %q, want empty", got)
+	}
+}
+
+func TestIAMUserRequests(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceIAMUser().Schema, map[string]interface{}{
+		"email": "engineer@example.com",
+		"name":  "Engineer",
+		"role":  "Engineers",
+	})
+	role := iamRole{ID: 2009, Name: "Engineers"}
+
+	tests := []struct {
+		name string
+		opts interface{}
+		want string
+	}{
+		{
+			name: "invite",
+			opts: iamInviteUserRequest(d, 5, role),
+			want: `{"client_id":5,"email":"engineer@example.com","name":"Engineer","lang":"en","user_role":{"id":2009,"name":"Engineers"}}`,
+		},
+		{
+			name: "update",
+			opts: iamUpdateUserRequest(d, role),
+			want: `{"name":"Engineer","lang":"en","groups":[{"id":2009,"name":"Engineers"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("request = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIAMUserProjectRoles(t *testing.T) {
+	var assignments userAssignments
+	body := `{"count":2,"results":[{"id":1,"role":"Observer","user_id":10,"client_id":5,"project_id":null},{"id":2,"role":"ProjectAdministrator","user_id":10,"client_id":5,"project_id":7}]}`
+	if err := json.Unmarshal([]byte(body), &assignments); err != nil {
+		t.Fatal(err)
+	}
+
+	got := iamUserProjectRoles(assignments.Results)
+	want := []interface{}{
+		map[string]interface{}{"role": "Observer", "project_id": 0},
+		map[string]interface{}{"role": "ProjectAdministrator", "project_id": 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("iamUserProjectRoles() = %v, want %v", got, want)
+	}
+}
@@ -31,6 +31,7 @@ const (
 
 	InstanceVMStateActive  = "active"
 	InstanceVMStateStopped = "stopped"
+	InstanceVMStateError   = "error"
 )
 
 func resourceInstance() *schema.Resource {
@@ -982,9 +983,19 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	var delOpts instances.DeleteOpts
+	if err := deleteInstance(client, instanceID, delOpts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of Instance deleting")
+	return diags
+}
+
+func deleteInstance(client *gcorecloud.ServiceClient, instanceID string, delOpts instances.DeleteOpts) error {
 	results, err := instances.Delete(client, instanceID, delOpts).Extract()
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 	taskID := results.Tasks[0]
 	log.Printf("[DEBUG] Task id (%s)", taskID)
@@ -1000,13 +1011,7 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m inter
 			return nil, err
 		}
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId("")
-	log.Printf("[DEBUG] Finish of Instance deleting")
-	return diags
+	return err
 }
 
 // ServerV2StateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
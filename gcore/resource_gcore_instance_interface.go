@@ -0,0 +1,475 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const InstanceInterfaceTimeoutMinutes = 10
+
+func resourceInstanceInterface() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInstanceInterfaceCreate,
+		ReadContext:   resourceInstanceInterfaceRead,
+		UpdateContext: resourceInstanceInterfaceUpdate,
+		DeleteContext: resourceInstanceInterfaceDelete,
+		CustomizeDiff: validateInstanceInterfaceSecurityGroupNames,
+		Description: `
+Represent interface attached to an instance. It allows attaching and detaching interfaces independently of the instance
+resource. The instance resource reads all interfaces of the instance, so add 'interface' to 'ignore_changes'
+of the instance resource to keep it from detaching interfaces managed by this resource.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(InstanceInterfaceTimeoutMinutes * time.Minute),
+			Delete: schema.DefaultTimeout(InstanceInterfaceTimeoutMinutes * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, portID, instanceID, err := ImportStringParserExtended(d.Id())
+
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("instance_id", instanceID)
+				d.SetId(portID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"instance_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance to attach the interface to",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of interface, should be unique for the instance",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("Available value is '%s'. The API doesn't tell 'any_subnet' from 'subnet', an imported interface is read as 'subnet'", strings.Join(instanceInterfaceTypes, "', '")),
+				ValidateFunc: validation.StringInSlice(instanceInterfaceTypes, false),
+			},
+			"network_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "required if type is 'any_subnet'",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"subnet_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "required if type is 'subnet'",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"port_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "required if type is 'reserved_fixed_ip'",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"ip_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "IP family for the interface, available values are 'dual', 'ipv4' and 'ipv6'",
+				ValidateFunc: validation.StringInSlice([]string{types.IPv4IPFamilyType.String(), types.IPv6IPFamilyType.String(), types.DualStackIPFamilyType.String()}, false),
+			},
+			"security_groups": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "list of security group IDs of the interface port. Changes are applied in place by assigning the groups by name, the API has no way to assign them by ID, so the groups must have distinct names",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fip_source": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("Attach floating IP to the interface, available values are '%s' and '%s'. An imported floating IP is read as '%s'", types.NewFloatingIP, types.ExistingFloatingIP, types.ExistingFloatingIP),
+				ValidateFunc: validation.StringInSlice([]string{types.NewFloatingIP.String(), types.ExistingFloatingIP.String()}, false),
+			},
+			"existing_fip_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The id of the existing floating IP that will be attached to the interface, required if fip_source is 'existing'",
+				RequiredWith: []string{"fip_source"},
+			},
+			"fip_address": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Floating IP address of the interface",
+			},
+			"ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipv6_address": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IPv6 address of a dual stack interface, 'ip_address' holds the IPv4 one",
+			},
+			"mac_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceInstanceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance interface creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	name := d.Get("name").(string)
+	iType := types.InterfaceType(d.Get("type").(string))
+	opts := instances.InterfaceInstanceCreateOpts{
+		InterfaceOpts: instances.InterfaceOpts{
+			Name:     &name,
+			Type:     iType,
+			IPFamily: types.IPFamilyType(d.Get("ip_family").(string)),
+		},
+	}
+	switch iType {
+	case types.SubnetInterfaceType:
+		opts.SubnetID = d.Get("subnet_id").(string)
+	case types.AnySubnetInterfaceType:
+		opts.NetworkID = d.Get("network_id").(string)
+	case types.ReservedFixedIpType:
+		opts.PortID = d.Get("port_id").(string)
+	}
+	if fipSource := d.Get("fip_source").(string); fipSource != "" {
+		opts.FloatingIP = &instances.CreateNewInterfaceFloatingIPOpts{
+			Source:             types.FloatingIPSource(fipSource),
+			ExistingFloatingID: d.Get("existing_fip_id").(string),
+		}
+	}
+	for _, sgID := range d.Get("security_groups").(*schema.Set).List() {
+		opts.SecurityGroups = append(opts.SecurityGroups, gcorecloud.ItemID{ID: sgID.(string)})
+	}
+
+	log.Printf("[DEBUG] attach interface: %+v", opts)
+	results, err := instances.AttachInterface(client, instanceID, opts).Extract()
+	if err != nil {
+		return diag.Errorf("cannot attach interface %s to instance %s. Error: %s", name, instanceID, err)
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] attach interface taskID: %s", taskID)
+	if err := tasks.WaitForStatus(client, string(taskID), tasks.TaskStateFinished, int(d.Timeout(schema.TimeoutCreate).Seconds()), true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ifs, err := instances.ListInterfacesAll(client, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	iface, ok := findInstanceInterfaceByName(ifs, name)
+	if !ok {
+		return diag.Errorf("interface %s is not found on instance %s after attaching", name, instanceID)
+	}
+	d.SetId(iface.PortID)
+
+	resourceInstanceInterfaceRead(ctx, d, m)
+
+	log.Printf("[DEBUG] Finish Instance interface creating (%s)", iface.PortID)
+	return nil
+}
+
+func resourceInstanceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance interface reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+	portID := d.Id()
+	instanceID := d.Get("instance_id").(string)
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ifs, err := instances.ListInterfacesAll(client, instanceID)
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			log.Printf("[WARN] Removing interface %s because instance %s doesn't exist anymore", portID, instanceID)
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	var iface *instances.Interface
+	for i := range ifs {
+		if ifs[i].PortID == portID {
+			iface = &ifs[i]
+			break
+		}
+	}
+	if iface == nil {
+		log.Printf("[WARN] Removing interface %s because it is not attached to instance %s anymore", portID, instanceID)
+		d.SetId("")
+		return nil
+	}
+
+	ports, err := instances.ListPortsAll(client, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var port *instances.InstancePorts
+	if p, err := findInstancePort(portID, ports); err == nil {
+		port = &p
+	}
+
+	rfipClient, err := CreateClient(provider, d, reservedFixedIPsPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	reserved := true
+	if _, err := reservedfixedips.Get(rfipClient, portID).Extract(); err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			reserved = false
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	setInstanceInterfaceData(d, *iface, port, reserved)
+
+	fields := []string{"project_id", "region_id"}
+	revertState(d, &fields)
+
+	log.Println("[DEBUG] Finish Instance interface reading")
+	return diags
+}
+
+func resourceInstanceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance interface updating")
+	config := m.(*Config)
+	provider := config.Provider
+	portID := d.Id()
+	instanceID := d.Get("instance_id").(string)
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("security_groups") {
+		sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ports, err := instances.ListPortsAll(client, instanceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		port, err := findInstancePort(portID, ports)
+		if err != nil {
+			return diag.Errorf("cannot find port %s of instance %s", portID, instanceID)
+		}
+		oldSGs, newSGs := d.GetChange("security_groups")
+		err = updateInstanceV2PortSecurityGroups(client, sgClient, instanceID, portID, port.SecurityGroups, oldSGs.(*schema.Set), newSGs.(*schema.Set), instanceV2SecurityGroupsModeReplace, "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish Instance interface updating")
+	return resourceInstanceInterfaceRead(ctx, d, m)
+}
+
+func resourceInstanceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance interface deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+	portID := d.Id()
+	instanceID := d.Get("instance_id").(string)
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := instances.InterfaceOpts{
+		PortID:    portID,
+		IpAddress: d.Get("ip_address").(string),
+	}
+	log.Printf("[DEBUG] detach interface: %+v", opts)
+	results, err := instances.DetachInterface(client, instanceID, opts).Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			d.SetId("")
+			log.Printf("[DEBUG] Finish of Instance interface deleting")
+			return diags
+		default:
+			return diag.FromErr(err)
+		}
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] detach interface taskID: %s", taskID)
+	if err := tasks.WaitForStatus(client, string(taskID), tasks.TaskStateFinished, int(d.Timeout(schema.TimeoutDelete).Seconds()), true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of Instance interface deleting")
+	return diags
+}
+
+// setInstanceInterfaceData sets the interface attributes read from the instance interface and its port
+func setInstanceInterfaceData(d *schema.ResourceData, iface instances.Interface, port *instances.InstancePorts, reserved bool) {
+	if iface.Name != nil {
+		d.Set("name", *iface.Name)
+	}
+	d.Set("type", instanceInterfaceType(iface, reserved, types.InterfaceType(d.Get("type").(string))).String())
+	d.Set("network_id", iface.NetworkID)
+	d.Set("port_id", iface.PortID)
+	d.Set("mac_address", iface.MacAddress.String())
+	d.Set("ip_address", "")
+	d.Set("ipv6_address", "")
+	if len(iface.IPAssignments) > 0 {
+		assignments, ipv6Address := instanceV2InterfaceAssignments(iface.IPAssignments)
+		assignment := instanceV2InterfaceAssignment(assignments, d.Get("subnet_id").(string))
+		d.Set("subnet_id", assignment.SubnetID)
+		d.Set("ip_address", assignment.IPAddress.String())
+		d.Set("ipv6_address", ipv6Address)
+		d.Set("ip_family", instanceV2InterfaceIPFamily(iface.IPAssignments).String())
+	}
+
+	d.Set("fip_address", "")
+	if len(iface.FloatingIPDetails) > 0 {
+		fip := iface.FloatingIPDetails[0]
+		d.Set("fip_address", fip.FloatingIPAddress.String())
+		// a new floating IP can't be told from an existing one, keep the known source
+		if d.Get("fip_source").(string) != types.NewFloatingIP.String() {
+			d.Set("fip_source", types.ExistingFloatingIP.String())
+			d.Set("existing_fip_id", fip.ID)
+		}
+	} else {
+		d.Set("fip_source", "")
+		d.Set("existing_fip_id", "")
+	}
+
+	if port != nil {
+		sgs := make([]interface{}, 0, len(port.SecurityGroups))
+		for _, sg := range port.SecurityGroups {
+			sgs = append(sgs, sg.ID)
+		}
+		d.Set("security_groups", schema.NewSet(schema.HashString, sgs))
+	}
+}
+
+// instanceInterfaceType returns type of the interface by its port. The API doesn't keep how the subnet of a port
+// was chosen, so 'any_subnet' is kept when it is known and 'subnet' is used otherwise.
+func instanceInterfaceType(iface instances.Interface, reserved bool, known types.InterfaceType) types.InterfaceType {
+	switch {
+	case reserved:
+		return types.ReservedFixedIpType
+	case iface.NetworkDetails.External:
+		return types.ExternalInterfaceType
+	case known == types.AnySubnetInterfaceType:
+		return types.AnySubnetInterfaceType
+	default:
+		return types.SubnetInterfaceType
+	}
+}
+
+// validateInstanceInterfaceSecurityGroupNames fails the plan when the security groups changed in place share a name,
+// they are assigned to the port by name
+func validateInstanceInterfaceSecurityGroupNames(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("security_groups") {
+		return nil
+	}
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("security_groups") {
+		return nil
+	}
+	groups := [][]string{securityGroupConfigIDs(config.GetAttr("security_groups"))}
+	return validateSecurityGroupNamesUnique(m.(*Config).Provider, diff, groups)
+}
+
+func findInstanceInterfaceByName(ifs []instances.Interface, name string) (instances.Interface, bool) {
+	for _, iface := range ifs {
+		if iface.Name != nil && *iface.Name == name {
+			return iface, true
+		}
+	}
+	return instances.Interface{}, false
+}
@@ -0,0 +1,162 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFindInstanceInterfaceByName(t *testing.T) {
+	public, private := "public", "private"
+	ifs := []instances.Interface{
+		{PortID: "unnamed"},
+		{PortID: "port-1", Name: &public},
+		{PortID: "port-2", Name: &private},
+	}
+
+	iface, ok := findInstanceInterfaceByName(ifs, "private")
+	if !ok || iface.PortID != "port-2" {
+		t.Errorf("findInstanceInterfaceByName() = %v, %v, want port-2, true", iface.PortID, ok)
+	}
+	if _, ok := findInstanceInterfaceByName(ifs, "missing"); ok {
+		t.Errorf("findInstanceInterfaceByName() expected no interface for missing name")
+	}
+	if _, ok := findInstanceInterfaceByName(ifs, ""); ok {
+		t.Errorf("findInstanceInterfaceByName() expected no interface for empty name")
+	}
+}
+
+func TestInstanceInterfaceImport(t *testing.T) {
+	r := resourceInstanceInterface()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("1:76:port-id:instance-id")
+	result, err := r.Importer.StateContext(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("import returned %d resources, want 1", len(result))
+	}
+	got := result[0]
+	if got.Id() != "port-id" {
+		t.Errorf("id = %q, want %q", got.Id(), "port-id")
+	}
+	want := map[string]interface{}{"project_id": 1, "region_id": 76, "instance_id": "instance-id"}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("%s = %v, want %v", key, got.Get(key), value)
+		}
+	}
+
+	for _, id := range []string{"1:76:port-id", "project:76:port-id:instance-id"} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		d.SetId(id)
+		if _, err := r.Importer.StateContext(context.Background(), d, nil); err == nil {
+			t.Errorf("import of %q expected error", id)
+		}
+	}
+}
+
+func TestInstanceInterfaceImportPlansNoChanges(t *testing.T) {
+	name := "public"
+	iface := instances.Interface{
+		Name:      &name,
+		PortID:    "port-id",
+		NetworkID: "network-id",
+		IPAssignments: []instances.PortIP{
+			{IPAddress: net.ParseIP("192.0.2.10"), SubnetID: "subnet-id"},
+			{IPAddress: net.ParseIP("2001:db8::10"), SubnetID: "subnet-v6-id"},
+		},
+		FloatingIPDetails: []instances.FloatingIP{{ID: "fip-id", FloatingIPAddress: net.ParseIP("198.51.100.10")}},
+	}
+	port := &instances.InstancePorts{ID: "port-id", SecurityGroups: []gcorecloud.ItemIDName{{ID: "sg-id", Name: "web"}}}
+
+	tests := []struct {
+		name     string
+		reserved bool
+		config   map[string]interface{}
+	}{
+		{
+			name: "subnet",
+			config: map[string]interface{}{
+				"subnet_id":       "subnet-id",
+				"type":            types.SubnetInterfaceType.String(),
+				"ip_family":       types.DualStackIPFamilyType.String(),
+				"fip_source":      types.ExistingFloatingIP.String(),
+				"existing_fip_id": "fip-id",
+				"security_groups": []interface{}{"sg-id"},
+			},
+		},
+		{
+			name:     "reserved fixed ip",
+			reserved: true,
+			config: map[string]interface{}{
+				"port_id": "port-id",
+				"type":    types.ReservedFixedIpType.String(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resourceInstanceInterface()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+			d.SetId("1:76:port-id:instance-id")
+			result, err := r.Importer.StateContext(context.Background(), d, nil)
+			if err != nil {
+				t.Fatalf("import error = %v", err)
+			}
+			imported := result[0]
+			setInstanceInterfaceData(imported, iface, port, tt.reserved)
+
+			config := map[string]interface{}{
+				"project_id":  1,
+				"region_id":   76,
+				"instance_id": "instance-id",
+				"name":        name,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			diff, err := r.Diff(context.Background(), imported.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("diff error = %v", err)
+			}
+			if diff != nil && len(diff.Attributes) > 0 {
+				for k, attr := range diff.Attributes {
+					t.Errorf("%s: %q => %q", k, attr.Old, attr.New)
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceInterfaceType(t *testing.T) {
+	external := instances.Interface{NetworkDetails: instances.NetworkDetail{External: true}}
+	tests := []struct {
+		name     string
+		iface    instances.Interface
+		reserved bool
+		known    types.InterfaceType
+		want     types.InterfaceType
+	}{
+		{"imported", instances.Interface{}, false, "", types.SubnetInterfaceType},
+		{"any subnet", instances.Interface{}, false, types.AnySubnetInterfaceType, types.AnySubnetInterfaceType},
+		{"external", external, false, "", types.ExternalInterfaceType},
+		{"reserved", external, true, types.SubnetInterfaceType, types.ReservedFixedIpType},
+	}
+	for _, tt := range tests {
+		if got := instanceInterfaceType(tt.iface, tt.reserved, tt.known); got != tt.want {
+			t.Errorf("%s: instanceInterfaceType() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"slices"
+	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
@@ -18,16 +20,27 @@ import (
 	instancesV2 "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/instances"
 	typesV2 "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	volumesV2 "github.com/G-Core/gcorelabscloud-go/gcore/volume/v2/volumes"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
 	instanceOperationTimeout = 1200
+
+	// instanceV2CreateTimeout bounds the instance creation including retries of create_retry policy
+	instanceV2CreateTimeout = 60 * time.Minute
+	// instanceV2CreateMaxBackoff caps the doubling delay between retries of create_retry policy
+	instanceV2CreateMaxBackoff = 5 * time.Minute
+
+	// instanceDescriptionMetadataKey is the metadata key the instance description is kept in
+	instanceDescriptionMetadataKey = "description"
 )
 
 func resourceInstanceV2() *schema.Resource {
@@ -35,11 +48,29 @@ func resourceInstanceV2() *schema.Resource {
 		CreateContext: resourceInstanceV2Create,
 		ReadContext:   resourceInstanceV2Read,
 		UpdateContext: resourceInstanceV2Update,
-		DeleteContext: resourceInstanceDelete,
+		DeleteContext: resourceInstanceV2Delete,
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+				if _, ok := diff.GetOk("description"); !ok {
+					return nil
+				}
+				if _, ok := diff.Get("metadata_map").(map[string]interface{})[instanceDescriptionMetadataKey]; ok {
+					return fmt.Errorf("metadata_map key %q conflicts with description attribute", instanceDescriptionMetadataKey)
+				}
+				return nil
+			},
+			validateInstanceV2Interfaces,
+			validateInstanceV2SecurityGroupNames,
+			validateInstanceV2VolumeSizes,
+			validateInstanceV2BootVolumeTermination,
+		),
 		Description: `
 Gcore Instance offer a flexible, powerful, and scalable solution for hosting applications and services.
 Designed to meet a wide range of computing needs, our instances ensure optimal performance, reliability, and security for
 your applications.`,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(instanceV2CreateTimeout),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, InstanceID, err := ImportStringParser(d.Id())
@@ -99,6 +130,12 @@ your applications.`,
 				Required:    true,
 				Description: "Flavor ID",
 			},
+			"allow_cold_resize": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stop the running instance for the flavor change (cold resize). The instance is started again after the resize, also when it fails, unless 'vm_state' is stopped. It causes downtime.",
+			},
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -110,6 +147,11 @@ your applications.`,
 				Optional:    true,
 				Description: "Instance name template. You can use forms 'ip_octets', 'two_ip_octets', 'one_ip_octet'",
 			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Instance description, eg. ownership info. It is kept in the 'description' metadata item of the instance and shown in the portal.",
+			},
 			"volume": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
@@ -142,7 +184,8 @@ by adding the volume resource inside an instance resource.`,
 						},
 						"size": {
 							Type:        schema.TypeInt,
-							Description: "Size of the volume in GiB",
+							Description: "Size of the volume in GiB. The volume is extended in place when the size grows, it cannot be shrunk",
+							Optional:    true,
 							Computed:    true,
 						},
 						"volume_id": {
@@ -160,26 +203,33 @@ by adding the volume resource inside an instance resource.`,
 						},
 						"delete_on_termination": {
 							Type:        schema.TypeBool,
-							Description: "Delete volume on termination",
+							Description: "Delete the volume together with the instance. It cannot be false for the boot volume, which the API always deletes on termination. A change on an attached volume is applied when the instance is deleted",
+							Optional:    true,
+							Computed:    true,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Description: "Device path of the attached volume inside the instance, eg. /dev/vdb",
 							Computed:    true,
 						},
 					},
 				},
 			},
 			"interface": &schema.Schema{
-				Type:     schema.TypeSet,
-				Set:      instanceInterfaceUniqueID,
+				Type:     schema.TypeList,
 				Required: true,
+				MinItems: 1,
 				Description: `
-List of interfaces for the instance. You can detach the interface from the instance by removing the
-interface from the instance resource and attach the interface by adding the interface resource
-inside an instance resource.`,
+List of interfaces for the instance. Interfaces are matched with the interfaces of the instance by name.
+You can detach the interface from the instance by removing the interface from the instance resource
+and attach the interface by adding the interface resource inside an instance resource.`,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: fmt.Sprintf("Available value is '%s', '%s', '%s', '%s'", types.SubnetInterfaceType, types.AnySubnetInterfaceType, types.ExternalInterfaceType, types.ReservedFixedIpType),
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  fmt.Sprintf("Available value is '%s'", strings.Join(instanceInterfaceTypes, "', '")),
+							ValidateFunc: validation.StringInSlice(instanceInterfaceTypes, false),
 						},
 						"name": {
 							Type:        schema.TypeString,
@@ -194,8 +244,15 @@ inside an instance resource.`,
 						"ip_family": {
 							Type:        schema.TypeString,
 							Optional:    true,
+							Computed:    true,
 							Description: "IP family for the interface, available values are 'dual', 'ipv4' and 'ipv6'",
 						},
+						"ipv6_enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Request dual stack allocation for the interface, eg. an external one, without setting 'ip_family'. The assigned IPv6 address is reported in 'ipv6_address'",
+						},
 						"network_id": {
 							Type:        schema.TypeString,
 							Description: "required if type is 'subnet' or 'any_subnet'",
@@ -223,7 +280,7 @@ inside an instance resource.`,
 						"security_groups": {
 							Type:        schema.TypeSet,
 							Required:    true,
-							Description: "list of security group IDs, they will be attached to exact interface",
+							Description: "list of security group IDs, they will be attached to exact interface. Changes of an existing interface are applied by assigning the groups by name, the API has no way to assign them by ID, so the groups must have distinct names",
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
 						"ip_address": {
@@ -232,9 +289,34 @@ inside an instance resource.`,
 							Optional:    true,
 							Description: "IP address for the interface.",
 						},
+						"disable_dhcp": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether DHCP is disabled in the subnet of the interface, eg. for PXE boot or network appliance images. It is set by 'enable_dhcp' of the subnet",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "MAC address of the interface",
+						},
+						"ipv6_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IPv6 address of a dual stack interface, 'ip_address' holds the IPv4 one",
+						},
 					},
 				},
 			},
+			"security_groups_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  instanceV2SecurityGroupsModeAppend,
+				Description: fmt.Sprintf(`
+How security groups of the interfaces are managed. With '%s' interface ports get exactly the configured security groups,
+groups assigned outside of terraform are unassigned. With '%s' only the configured groups are assigned and unassigned,
+groups assigned outside of terraform are kept and ignored.`, instanceV2SecurityGroupsModeReplace, instanceV2SecurityGroupsModeAppend),
+				ValidateFunc: validation.StringInSlice([]string{instanceV2SecurityGroupsModeReplace, instanceV2SecurityGroupsModeAppend}, false),
+			},
 			"keypair_name": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -303,11 +385,27 @@ For Windows instances, Admin user password is set by 'password' field and cannot
 				Description: `If true, application ports will be allowed in the security group for instances created
 				from the marketplace application template`,
 			},
+			"app_ports_security_group_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"allow_app_ports"},
+				Description:  "Name of the security group created for the application ports. The generated name is kept when omitted.",
+			},
+			"app_ports_security_group_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group created for the application ports when 'allow_app_ports' is true.",
+			},
 			"flavor": &schema.Schema{
 				Type:        schema.TypeMap,
 				Description: "Flavor details, RAM, vCPU, etc.",
 				Computed:    true,
 			},
+			"actual_flavor_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "ID of the flavor the instance runs on. It differs from 'flavor_id' when the instance was created with one of 'create_retry.alternative_flavor_ids'.",
+				Computed:    true,
+			},
 			"status": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Status of the instance",
@@ -322,6 +420,36 @@ For Windows instances, Admin user password is set by 'password' field and cannot
 					InstanceVMStateActive, InstanceVMStateStopped,
 				}, true),
 			},
+			"create_retry": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry policy for instance creation failed due to temporarily unavailable flavor capacity. Used only on create.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Number of create attempts for each flavor.",
+						},
+						"backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Delay in seconds before the first retry, doubled after every failed attempt up to 5 minutes. All retries are bounded by the create timeout.",
+						},
+						"alternative_flavor_ids": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Flavors tried in order when all attempts with 'flavor_id' failed. The flavor actually used is reported in 'actual_flavor_id', 'flavor_id' keeps the configured value.",
+						},
+					},
+				},
+			},
 			"addresses": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "List of instance addresses",
@@ -349,6 +477,16 @@ For Windows instances, Admin user password is set by 'password' field and cannot
 					},
 				},
 			},
+			"primary_ipv4": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Primary IPv4 address of the instance: the first floating or public address, otherwise the first fixed address. Networks are taken in alphabetical order.",
+			},
+			"primary_ipv6": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Primary IPv6 address of the instance, chosen by the same rule as 'primary_ipv4'.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -401,10 +539,14 @@ func resourceInstanceV2Create(ctx context.Context, d *schema.ResourceData, m int
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		// existing volumes are attached with their size, they are extended after the instance is created
+		for i := range vs {
+			vs[i].Size = 0
+		}
 		createOpts.Volumes = vs
 	}
 
-	ifs := d.Get("interface").(*schema.Set).List()
+	ifs := d.Get("interface").([]interface{})
 	// sort interfaces by 'order' key to attach it in right order
 	sort.Sort(instanceInterfaces(ifs))
 	if len(ifs) > 0 {
@@ -420,6 +562,16 @@ func resourceInstanceV2Create(ctx context.Context, d *schema.ResourceData, m int
 		createOpts.Metadata = &md
 	}
 
+	if description, ok := d.GetOk("description"); ok {
+		if createOpts.Metadata == nil {
+			createOpts.Metadata = &instances.MetadataSetOpts{}
+		}
+		createOpts.Metadata.Metadata = append(createOpts.Metadata.Metadata, instances.MetadataOpts{
+			Key:   instanceDescriptionMetadataKey,
+			Value: description.(string),
+		})
+	}
+
 	configuration := d.Get("configuration")
 	if len(configuration.([]interface{})) > 0 {
 		conf, err := extractKeyValue(configuration.([]interface{}))
@@ -430,31 +582,29 @@ func resourceInstanceV2Create(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	log.Printf("[DEBUG] Interface create options: %+v", createOpts)
-	results, err := instances.Create(clientv2, createOpts).Extract()
+	InstanceID, flavorID, err := createInstanceV2WithRetry(ctx, clientv1, clientv2, createOpts, d.Get("create_retry").([]interface{}), d.Timeout(schema.TimeoutCreate))
+	log.Printf("[DEBUG] Instance id (%s)", InstanceID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	taskID := results.Tasks[0]
-	log.Printf("[DEBUG] Task id (%s)", taskID)
-	InstanceID, err := tasks.WaitTaskAndReturnResult(clientv1, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
-		taskInfo, err := tasks.Get(clientv1, string(task)).Extract()
-		if err != nil {
-			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
-		}
-		Instance, err := instances.ExtractInstanceIDFromTask(taskInfo)
-		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve Instance ID from task info: %w", err)
-		}
-		return Instance, nil
-	},
-	)
-	log.Printf("[DEBUG] Instance id (%s)", InstanceID)
+	d.SetId(InstanceID)
+	d.Set("actual_flavor_id", flavorID)
+
+	clientVol, err := CreateClient(provider, d, volumesPoint, versionPointV1)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := extendInstanceV2Volumes(clientVol, currentVols); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if createOpts.AllowAppPorts {
+		if err := setupInstanceV2AppPortsSecurityGroup(d, provider, clientv1, InstanceID, ifs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	d.SetId(InstanceID.(string))
 	resourceInstanceV2Read(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish Instance creating (%s)", InstanceID)
@@ -497,7 +647,7 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	d.Set("name", instance.Name)
-	d.Set("flavor_id", instance.Flavor.FlavorID)
+	readInstanceV2FlavorID(d, instance.Flavor.FlavorID)
 	d.Set("status", instance.Status)
 	d.Set("vm_state", instance.VMState)
 
@@ -519,7 +669,7 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 			v["volume_id"] = vol.ID
 		}
 		v["id"] = vol.ID
-		v["delete_on_termination"] = vol.DeleteOnTermination
+		v["delete_on_termination"] = readInstanceV2DeleteOnTermination(d.GetRawConfig(), currentVolumes, vol)
 
 		volume, err := volumes.Get(clientVol, vol.ID).Extract()
 		if err != nil {
@@ -527,6 +677,12 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 		}
 		v["size"] = volume.Size
 		v["type_name"] = volume.VolumeType.String()
+		v["device"] = ""
+		for _, attachment := range volume.Attachments {
+			if attachment.ServerID == instanceID {
+				v["device"] = attachment.Device
+			}
+		}
 
 		extVolumes = append(extVolumes, v)
 	}
@@ -544,68 +700,106 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	statesInterface := d.Get("interface").(*schema.Set)
-	interfaces, err := extractInstanceInterfaceIntoMapV2(statesInterface.List())
+	statesInterface := d.Get("interface").([]interface{})
+	interfaces, err := extractInstanceInterfaceIntoMapV2(statesInterface)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var cleanInterfaces []interface{}
+	appPortsSG := ""
+	if d.Get("allow_app_ports").(bool) {
+		appPortsSG = readInstanceV2AppPortsSecurityGroup(instancePorts, statesInterface, d.Get("app_ports_security_group_id").(string))
+	}
+	d.Set("app_ports_security_group_id", appPortsSG)
+
+	stateSubnets := make(map[string]string)
+	stateSGs := make(map[string]*schema.Set)
+	for _, raw := range statesInterface {
+		iface := raw.(map[string]interface{})
+		stateSGs[iface["name"].(string)], _ = iface["security_groups"].(*schema.Set)
+		stateSubnets[iface["name"].(string)], _ = iface["subnet_id"].(string)
+	}
+
+	sgMode := d.Get("security_groups_mode").(string)
+
+	subnetClient, err := CreateClient(provider, d, subnetPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subnetDHCP := make(map[string]bool)
+
+	// every interface of the instance is kept once by name, multiple assignments of a port are
+	// reduced to the one in the subnet known to the state
+	readInterfaces := make(map[string]map[string]interface{})
+	var readNames []string
 	for ifOrder, iface := range ifs {
 		if len(iface.IPAssignments) == 0 {
 			continue
 		}
 
-		// we need to match our interfaces with api's interfaces
-		// but we don't have any unique values, that's why we use exactly that list of keys
+		// dual stack interface has IPv4 and IPv6 assignments, keep them as a single interface
+		assignments, ipv6Address := instanceV2InterfaceAssignments(iface.IPAssignments)
+
 		ifaceName := iface.Name
 		if ifaceName == nil {
 			log.Printf("[WARN] Interface for instance %s missing name. Using PortID as identifier.", instanceID)
 			generatedName := fmt.Sprintf("interface_%s", iface.PortID)
 			ifaceName = &generatedName
 		}
+		if _, ok := readInterfaces[*ifaceName]; ok {
+			log.Printf("[WARN] Instance %s has several interfaces named %s, only the first one is tracked", instanceID, *ifaceName)
+			continue
+		}
+		assignment := instanceV2InterfaceAssignment(assignments, stateSubnets[*ifaceName])
 
-		for _, assignment := range iface.IPAssignments {
-			subnetID := assignment.SubnetID
-
-			// bad idea, but what to do
-			var iOpts instances.InterfaceOpts
-			var orderedIOpts OrderedInterfaceOpts
-			var ok bool
-
-			if orderedIOpts, ok = interfaces[*ifaceName]; ok {
-				iOpts = orderedIOpts.InterfaceOpts
-			}
-
-			i := make(map[string]interface{})
-			if !ok {
-				orderedIOpts = OrderedInterfaceOpts{Order: ifOrder}
-			} else {
-				i["type"] = iOpts.Type.String()
-			}
+		var iOpts instances.InterfaceOpts
+		orderedIOpts, ok := interfaces[*ifaceName]
+		if ok {
+			iOpts = orderedIOpts.InterfaceOpts
+		}
 
-			i["network_id"] = iface.NetworkID
-			i["subnet_id"] = subnetID
-			i["port_id"] = iface.PortID
-			i["name"] = *ifaceName
-			i["order"] = orderedIOpts.Order
-			if len(iface.FloatingIPDetails) > 0 {
-				i["existing_fip_id"] = iface.FloatingIPDetails[0].ID
-			}
-			i["ip_address"] = assignment.IPAddress.String()
+		i := make(map[string]interface{})
+		if !ok {
+			orderedIOpts = OrderedInterfaceOpts{Order: ifOrder}
+		} else {
+			i["type"] = iOpts.Type.String()
+		}
 
-			if port, err := findInstancePort(iface.PortID, instancePorts); err == nil {
-				sgs := make([]interface{}, len(port.SecurityGroups))
-				for i, sg := range port.SecurityGroups {
-					sgs[i] = sg.ID
+		i["network_id"] = iface.NetworkID
+		i["subnet_id"] = assignment.SubnetID
+		i["port_id"] = iface.PortID
+		i["name"] = *ifaceName
+		i["order"] = orderedIOpts.Order
+		if len(iface.FloatingIPDetails) > 0 {
+			i["existing_fip_id"] = iface.FloatingIPDetails[0].ID
+		}
+		i["ip_address"] = assignment.IPAddress.String()
+		i["mac_address"] = iface.MacAddress.String()
+		if assignment.SubnetID != "" {
+			if _, ok := subnetDHCP[assignment.SubnetID]; !ok {
+				subnet, err := subnets.Get(subnetClient, assignment.SubnetID).Extract()
+				if err != nil {
+					return diag.FromErr(err)
 				}
-				i["security_groups"] = schema.NewSet(sgUniqueIDs, sgs)
+				subnetDHCP[assignment.SubnetID] = subnet.EnableDHCP
 			}
-
-			cleanInterfaces = append(cleanInterfaces, i)
+			i["disable_dhcp"] = !subnetDHCP[assignment.SubnetID]
 		}
+		ipFamily := instanceV2InterfaceIPFamily(iface.IPAssignments)
+		i["ip_family"] = ipFamily.String()
+		i["ipv6_enabled"] = ipFamily != types.IPv4IPFamilyType
+		i["ipv6_address"] = ipv6Address
+
+		if port, err := findInstancePort(iface.PortID, instancePorts); err == nil {
+			sgs := instanceV2PortSecurityGroups(port.SecurityGroups, stateSGs[*ifaceName], sgMode, appPortsSG)
+			i["security_groups"] = schema.NewSet(sgUniqueIDs, sgs)
+		}
+
+		readInterfaces[*ifaceName] = i
+		readNames = append(readNames, *ifaceName)
 	}
-	if err := d.Set("interface", schema.NewSet(instanceInterfaceUniqueID, cleanInterfaces)); err != nil {
+	cleanInterfaces := instanceV2OrderInterfaces(statesInterface, readNames, readInterfaces)
+	if err := d.Set("interface", cleanInterfaces); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -622,6 +816,17 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	description, err := instancesV2.MetadataItemGet(clientV2, instanceID, instancesV2.MetadataItemOpts{Key: instanceDescriptionMetadataKey}).Extract()
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if !errors.As(err, &errDefault404) {
+			return diag.Errorf("cannot get instance description. Error: %s", err)
+		}
+		d.Set("description", "")
+	} else {
+		d.Set("description", description.Value)
+	}
+
 	addresses := []map[string][]map[string]string{}
 	for _, data := range instance.Addresses {
 		d := map[string][]map[string]string{}
@@ -638,6 +843,9 @@ func resourceInstanceV2Read(ctx context.Context, d *schema.ResourceData, m inter
 	if err := d.Set("addresses", addresses); err != nil {
 		return diag.FromErr(err)
 	}
+	primaryIPv4, primaryIPv6 := instanceV2PrimaryAddresses(instance.Addresses)
+	d.Set("primary_ipv4", primaryIPv4)
+	d.Set("primary_ipv6", primaryIPv6)
 
 	log.Println("[DEBUG] Finish Instance reading")
 	return diags
@@ -676,24 +884,32 @@ func resourceInstanceV2Update(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	if d.HasChange("flavor_id") {
-		flavor_id := d.Get("flavor_id").(string)
-		results, err := instances.Resize(client, instanceID, instances.ChangeFlavorOpts{FlavorID: flavor_id}).Extract()
-		if err != nil {
-			return diag.FromErr(err)
+	if d.HasChange("app_ports_security_group_name") {
+		if sgID := d.Get("app_ports_security_group_id").(string); sgID != "" {
+			if err := updateInstanceV2AppPortsSecurityGroup(clientSg, sgID, d); err != nil {
+				return diag.FromErr(err)
+			}
 		}
-		taskID := results.Tasks[0]
-		log.Printf("[DEBUG] Task id (%s)", taskID)
-		taskState, err := tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
-			taskInfo, err := tasks.Get(client, string(task)).Extract()
+	}
+
+	var diags diag.Diagnostics
+	stoppedForResize := false
+	if d.HasChange("flavor_id") {
+		flavorID := d.Get("flavor_id").(string)
+		if d.Get("allow_cold_resize").(bool) {
+			keepStopped := d.Get("vm_state").(string) == InstanceVMStateStopped
+			stopped, err := coldResizeInstanceV2(client, clientV2, instanceID, flavorID, keepStopped)
+			if stopped {
+				stoppedForResize = keepStopped
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Instance %s was stopped to change flavor to %s", instanceID, flavorID),
+				})
+			}
 			if err != nil {
-				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+				return append(diags, diag.FromErr(err)...)
 			}
-			return taskInfo.State, nil
-		},
-		)
-		log.Printf("[DEBUG] Task state (%s)", taskState)
-		if err != nil {
+		} else if err := resizeInstanceV2(client, instanceID, flavorID); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -727,6 +943,25 @@ func resourceInstanceV2Update(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
+	if d.HasChange("description") {
+		if description := d.Get("description").(string); description == "" {
+			err := instancesV2.MetadataItemDelete(clientV2, instanceID, instancesV2.MetadataItemOpts{Key: instanceDescriptionMetadataKey}).Err
+			if err != nil {
+				var errDefault404 gcorecloud.ErrDefault404
+				if !errors.As(err, &errDefault404) {
+					return diag.Errorf("cannot delete instance description. Error: %s", err)
+				}
+			}
+		} else {
+			createOpts := instances.MetadataSetOpts{
+				Metadata: []instances.MetadataOpts{{Key: instanceDescriptionMetadataKey, Value: description}},
+			}
+			if err := instances.MetadataCreate(client, instanceID, createOpts).Err; err != nil {
+				return diag.Errorf("cannot set instance description. Error: %s", err)
+			}
+		}
+	}
+
 	if d.HasChange("interface") {
 		instancePorts, err := instances.ListPortsAll(client, instanceID)
 		if err != nil {
@@ -750,26 +985,21 @@ func resourceInstanceV2Update(ctx context.Context, d *schema.ResourceData, m int
 		}
 
 		ifsOldRaw, ifsNewRaw := d.GetChange("interface")
+		ifsOld := ifsOldRaw.([]interface{})
+		ifsNew := ifsNewRaw.([]interface{})
 
-		ifsOld := ifsOldRaw.(*schema.Set)
-		ifsNew := ifsNewRaw.(*schema.Set)
-
-		// we have to create separate sets for old and new interfaces by name, to be able to match
-		// interfaces which wasn't changed. We do it because new set doesn't contain portID.
-		// port id is needed to reassign security groups
-		ifsSetByNameOld := schema.NewSet(instanceInterfaceUniqueIDByName, ifsOld.List())
-		ifsSetByNameNew := schema.NewSet(instanceInterfaceUniqueIDByName, ifsNew.List())
+		// interfaces are matched by name, the new ones don't contain port id which is known only for the old ones.
+		// port id is needed to detach interfaces and to reassign security groups
+		ifsOldByName := instanceV2InterfacesByName(ifsOld)
+		ifsNewByName := instanceV2InterfacesByName(ifsNew)
 
-		ifsForUpdate := schema.NewSet(instanceInterfaceUniqueIDByName, []interface{}{})
-
-		for _, i := range ifsOld.Difference(ifsNew).List() {
-			// if name left the same in new set, we can skip detaching
-			if ifsSetByNameNew.Contains(i) {
-				ifsForUpdate.Add(i)
+		for _, i := range ifsOld {
+			iface := i.(map[string]interface{})
+			// if name left the same in new list, we can skip detaching
+			if newIface, ok := ifsNewByName[iface["name"].(string)]; ok && !instanceV2InterfaceReplaced(iface, newIface) {
 				continue
 			}
 
-			iface := i.(map[string]interface{})
 			var opts instances.InterfaceOpts
 			opts.PortID = iface["port_id"].(string)
 			opts.IpAddress = iface["ip_address"].(string)
@@ -793,76 +1023,36 @@ func resourceInstanceV2Update(ctx context.Context, d *schema.ResourceData, m int
 			}
 		}
 
-		ifsNewSorted := ifsNew.Difference(ifsOld).List()
-		sort.Sort(instanceInterfaces(ifsNewSorted))
-		for _, i := range ifsNewSorted {
+		ifsToAttach := make([]interface{}, 0)
+		for _, i := range ifsNew {
+			iface := i.(map[string]interface{})
+			oldIface, ok := ifsOldByName[iface["name"].(string)]
 			// if it is completely new interface we need to attach it
-			if !ifsSetByNameOld.Contains(i) {
-				if err := attachNewInterface(i, client, instanceID); err != nil {
-					return diag.FromErr(err)
-				}
+			if !ok || instanceV2InterfaceReplaced(oldIface, iface) {
+				ifsToAttach = append(ifsToAttach, i)
 				continue
 			}
-
-			iface := i.(map[string]interface{})
-
-			var portID string
-			// try to find port id from old interfaces
-			for _, iOld := range ifsForUpdate.List() {
-				interfaceOld := iOld.(map[string]interface{})
-				if interfaceOld["name"] == iface["name"] {
-					portID = interfaceOld["port_id"].(string)
-					break
-				}
+			if oldIface["security_groups"].(*schema.Set).Equal(iface["security_groups"]) {
+				continue
 			}
 
-			log.Println("[DEBUG] Reassign security groups")
+			portID := oldIface["port_id"].(string)
 			port, err := findInstancePort(portID, instancePorts)
 			if err != nil {
-				log.Println("[DEBUG] Port not found")
-				continue
+				return diag.Errorf("cannot reassign security groups of interface %s: %s", iface["name"], err)
 			}
-
-			// detach what should be detached
-			sgToDetach := make([]string, 0)
-			for _, sg := range port.SecurityGroups {
-				if !iface["security_groups"].(*schema.Set).Contains(sg.ID) {
-					sgToDetach = append(sgToDetach, sg.Name)
-				}
-			}
-			detachOpts := instances.SecurityGroupOpts{
-				PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{
-					PortID:             &portID,
-					SecurityGroupNames: sgToDetach,
-				}},
-			}
-			if err := instances.UnAssignSecurityGroup(client, instanceID, detachOpts).ExtractErr(); err != nil {
-				log.Printf("[WARNING] Cannot detach security groups: %v", err)
-			}
-
-			// attach what should be attached
-			sgToAttach := make([]string, 0)
-			for _, sg := range iface["security_groups"].(*schema.Set).List() {
-				if !slices.ContainsFunc(port.SecurityGroups, func(s gcorecloud.ItemIDName) bool {
-					return s.ID == sg.(string)
-				}) {
-					// get the name of the security group
-					secGroup, err := securitygroups.Get(clientSg, sg.(string)).Extract()
-					if err != nil {
-						log.Printf("[WARNING] Cannot get security group %s: %v", sg, err)
-						continue
-					}
-					sgToAttach = append(sgToAttach, secGroup.Name)
-				}
-			}
-			attachOpts := instances.SecurityGroupOpts{
-				PortsSecurityGroupNames: []instances.PortSecurityGroupNames{{
-					PortID:             &portID,
-					SecurityGroupNames: sgToAttach,
-				}},
+			sgMode := d.Get("security_groups_mode").(string)
+			appPortsSG := d.Get("app_ports_security_group_id").(string)
+			if err := updateInstanceV2PortSecurityGroups(client, clientSg, instanceID, portID, port.SecurityGroups, oldIface["security_groups"].(*schema.Set), iface["security_groups"].(*schema.Set), sgMode, appPortsSG); err != nil {
+				return diag.FromErr(err)
 			}
-			if err := instances.AssignSecurityGroup(client, instanceID, attachOpts).ExtractErr(); err != nil {
-				log.Printf("[WARNING] Cannot attach security groups: %v", err)
+		}
+
+		// sort interfaces by 'order' key to attach it in right order
+		sort.Sort(instanceInterfaces(ifsToAttach))
+		for _, i := range ifsToAttach {
+			if err := attachNewInterface(i, client, instanceID); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -909,52 +1099,290 @@ func resourceInstanceV2Update(ctx context.Context, d *schema.ResourceData, m int
 				}
 			}
 		}
+
+		clientVol, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := extendInstanceV2Volumes(clientVol, newVolumesRaw.(*schema.Set).List()); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if d.HasChange("vm_state") {
 		state := d.Get("vm_state").(string)
-		opts := instancesV2.ActionOpts{}
+		var action typesV2.InstanceActionType
 		switch state {
 		case InstanceVMStateActive:
-			opts.Action = typesV2.InstanceActionTypeStart
+			action = typesV2.InstanceActionTypeStart
 		case InstanceVMStateStopped:
-			opts.Action = typesV2.InstanceActionTypeStop
+			action = typesV2.InstanceActionTypeStop
 		}
 
-		results, err := instancesV2.Action(clientV2, instanceID, opts).Extract()
-		if err != nil {
+		// the instance stopped for cold resize is already in the desired state
+		if !(stoppedForResize && state == InstanceVMStateStopped) {
+			if err := instanceV2Action(client, clientV2, instanceID, action); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+	}
+
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+	log.Println("[DEBUG] Finish Instance updating")
+	return append(diags, resourceInstanceV2Read(ctx, d, m)...)
+}
+
+func resourceInstanceV2Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Instance deleting")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+	instanceID := d.Id()
+	log.Printf("[DEBUG] Instance id = %s", instanceID)
+
+	client, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instance, err := instances.Get(client, instanceID).Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			d.SetId("")
+			log.Printf("[DEBUG] Finish of Instance deleting")
+			return diags
+		default:
 			return diag.FromErr(err)
 		}
+	}
 
-		taskID := results.Tasks[0]
-		if err := waitInstanceOperation(client, taskID); err != nil {
+	toDelete, toDetach := instanceV2TerminationVolumes(instance.Volumes, d.Get("volume").(*schema.Set).List())
+	if len(toDetach) > 0 {
+		vClient, err := CreateClient(provider, d, volumesPoint, versionPointV2)
+		if err != nil {
 			return diag.FromErr(err)
 		}
+		vOpts := volumes.InstanceOperationOpts{InstanceID: instanceID}
+		for _, vid := range toDetach {
+			log.Printf("[DEBUG] Detach volume %s to keep it on instance termination", vid)
+			results, err := volumesV2.Detach(vClient, vid, vOpts).Extract()
+			if err != nil {
+				return diag.Errorf("cannot detach volume %s to keep it on instance termination, set delete_on_termination to delete it: %s", vid, err)
+			}
+			if err := waitInstanceOperation(client, results.Tasks[0]); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
-	d.Set("last_updated", time.Now().Format(time.RFC850))
-	log.Println("[DEBUG] Finish Instance updating")
-	return resourceInstanceV2Read(ctx, d, m)
+	delOpts := instances.DeleteOpts{Volumes: toDelete}
+	if err := deleteInstance(client, instanceID, delOpts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of Instance deleting")
+	return diags
 }
 
-func instanceInterfaceUniqueID(i interface{}) int {
-	e := i.(map[string]interface{})
-	h := md5.New()
-	securitygroupsRaw := e["security_groups"].(*schema.Set).List()
-	var securitygroups string
-	for _, sg := range securitygroupsRaw {
-		securitygroups += sg.(string)
+// validateInstanceV2VolumeSizes forbids shrinking of the attached volumes
+func validateInstanceV2VolumeSizes(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("volume") {
+		return nil
 	}
-	io.WriteString(h, e["name"].(string))
-	io.WriteString(h, securitygroups)
-	return int(binary.BigEndian.Uint64(h.Sum(nil)))
+	oldVolumes, newVolumes := diff.GetChange("volume")
+	oldSizes := make(map[string]int)
+	for _, raw := range oldVolumes.(*schema.Set).List() {
+		v := raw.(map[string]interface{})
+		oldSizes[v["volume_id"].(string)] = v["size"].(int)
+	}
+	for _, raw := range newVolumes.(*schema.Set).List() {
+		v := raw.(map[string]interface{})
+		volumeID := v["volume_id"].(string)
+		if size := v["size"].(int); size > 0 && size < oldSizes[volumeID] {
+			return fmt.Errorf("volume %s cannot be shrunk from %d to %d GiB", volumeID, oldSizes[volumeID], size)
+		}
+	}
+	return nil
 }
 
-func instanceInterfaceUniqueIDByName(i interface{}) int {
-	e := i.(map[string]interface{})
-	h := md5.New()
-	io.WriteString(h, e["name"].(string))
-	return int(binary.BigEndian.Uint64(h.Sum(nil)))
+// validateInstanceV2BootVolumeTermination forbids keeping the boot volume, the API deletes it with the instance
+func validateInstanceV2BootVolumeTermination(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if kept := instanceV2KeptBootVolumes(diff.GetRawConfig()); len(kept) > 0 {
+		return fmt.Errorf("delete_on_termination cannot be false for boot volumes: %s", strings.Join(kept, ", "))
+	}
+	return nil
+}
+
+// instanceV2KeptBootVolumes returns IDs of configured boot volumes with delete_on_termination set to false
+func instanceV2KeptBootVolumes(config cty.Value) []string {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("volume") {
+		return nil
+	}
+	vols := config.GetAttr("volume")
+	if vols.IsNull() || !vols.IsKnown() {
+		return nil
+	}
+
+	var kept []string
+	for it := vols.ElementIterator(); it.Next(); {
+		_, vol := it.Element()
+		if vol.IsNull() || !vol.IsKnown() {
+			continue
+		}
+		bootIndex, deleteOnTermination := vol.GetAttr("boot_index"), vol.GetAttr("delete_on_termination")
+		if bootIndex.IsNull() || !bootIndex.IsKnown() || !bootIndex.Equals(cty.Zero).True() {
+			continue
+		}
+		if deleteOnTermination.IsNull() || !deleteOnTermination.IsKnown() || deleteOnTermination.True() {
+			continue
+		}
+		volumeID := vol.GetAttr("volume_id")
+		if volumeID.IsNull() || !volumeID.IsKnown() {
+			kept = append(kept, "(known after apply)")
+			continue
+		}
+		kept = append(kept, volumeID.AsString())
+	}
+	return kept
+}
+
+// extendInstanceV2Volumes extends attached volumes up to the configured size
+func extendInstanceV2Volumes(client *gcorecloud.ServiceClient, vols []interface{}) error {
+	for _, raw := range vols {
+		v := raw.(map[string]interface{})
+		size, _ := v["size"].(int)
+		if size == 0 {
+			continue
+		}
+		volumeID := v["volume_id"].(string)
+		volume, err := volumes.Get(client, volumeID).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot get volume %s: %w", volumeID, err)
+		}
+		if size < volume.Size {
+			return fmt.Errorf("volume %s cannot be shrunk from %d to %d GiB", volumeID, volume.Size, size)
+		}
+		if size == volume.Size {
+			continue
+		}
+		log.Printf("[DEBUG] Extend volume %s from %d to %d GiB", volumeID, volume.Size, size)
+		if err := ExtendVolume(client, volumeID, size); err != nil {
+			return fmt.Errorf("cannot extend volume %s: %w", volumeID, err)
+		}
+	}
+	return nil
+}
+
+// readInstanceV2DeleteOnTermination returns delete_on_termination of the attached volume to store in the state.
+// The API cannot change it for an attached volume, so the configured value is kept and applied on the instance
+// deletion instead of being read back. The config is null on refresh and import, the state value is kept then.
+func readInstanceV2DeleteOnTermination(config cty.Value, current map[string]map[string]interface{}, vol instances.InstanceVolume) bool {
+	if !config.IsNull() && config.IsKnown() && config.Type().IsObjectType() && config.Type().HasAttribute("volume") {
+		vols := config.GetAttr("volume")
+		if vols.IsNull() || !vols.IsKnown() {
+			return vol.DeleteOnTermination
+		}
+		for it := vols.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			if v.IsNull() || !v.IsKnown() {
+				continue
+			}
+			volumeID, deleteOnTermination := v.GetAttr("volume_id"), v.GetAttr("delete_on_termination")
+			if volumeID.IsNull() || !volumeID.IsKnown() || volumeID.AsString() != vol.ID {
+				continue
+			}
+			if deleteOnTermination.IsNull() || !deleteOnTermination.IsKnown() {
+				break
+			}
+			return deleteOnTermination.True()
+		}
+		return vol.DeleteOnTermination
+	}
+	if v, ok := current[vol.ID]; ok {
+		if deleteOnTermination, ok := v["delete_on_termination"].(bool); ok {
+			return deleteOnTermination
+		}
+	}
+	return vol.DeleteOnTermination
+}
+
+// instanceV2TerminationVolumes returns volumes to be deleted with the instance and volumes to be detached before
+// the instance deletion to keep them, according to delete_on_termination of the resource
+func instanceV2TerminationVolumes(attached []instances.InstanceVolume, vols []interface{}) (toDelete, toDetach []string) {
+	deleteOnTermination := make(map[string]bool, len(vols))
+	for _, raw := range vols {
+		v := raw.(map[string]interface{})
+		deleteOnTermination[v["volume_id"].(string)], _ = v["delete_on_termination"].(bool)
+	}
+	for _, vol := range attached {
+		configured, ok := deleteOnTermination[vol.ID]
+		if !ok || configured == vol.DeleteOnTermination {
+			continue
+		}
+		if configured {
+			toDelete = append(toDelete, vol.ID)
+		} else {
+			toDetach = append(toDetach, vol.ID)
+		}
+	}
+	return toDelete, toDetach
+}
+
+func instanceV2Action(client, clientV2 *gcorecloud.ServiceClient, instanceID string, action typesV2.InstanceActionType) error {
+	results, err := instancesV2.Action(clientV2, instanceID, instancesV2.ActionOpts{Action: action}).Extract()
+	if err != nil {
+		return err
+	}
+	return waitInstanceOperation(client, results.Tasks[0])
+}
+
+func resizeInstanceV2(client *gcorecloud.ServiceClient, instanceID, flavorID string) error {
+	results, err := instances.Resize(client, instanceID, instances.ChangeFlavorOpts{FlavorID: flavorID}).Extract()
+	if err != nil {
+		return err
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	taskState, err := tasks.WaitTaskAndReturnResult(client, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return taskInfo.State, nil
+	},
+	)
+	log.Printf("[DEBUG] Task state (%s)", taskState)
+	return err
+}
+
+// coldResizeInstanceV2 stops the active instance for the flavor change. The instance is started again
+// afterwards, also when the resize fails, unless keepStopped is set. It reports whether the instance was stopped.
+func coldResizeInstanceV2(client, clientV2 *gcorecloud.ServiceClient, instanceID, flavorID string, keepStopped bool) (stopped bool, err error) {
+	instance, err := instances.Get(client, instanceID).Extract()
+	if err != nil {
+		return false, err
+	}
+	if instance.VMState != InstanceVMStateActive {
+		return false, resizeInstanceV2(client, instanceID, flavorID)
+	}
+
+	log.Printf("[DEBUG] Stopping instance %s for cold resize", instanceID)
+	if err := instanceV2Action(client, clientV2, instanceID, typesV2.InstanceActionTypeStop); err != nil {
+		return false, fmt.Errorf("cannot stop instance %s for cold resize: %w", instanceID, err)
+	}
+	defer func() {
+		if keepStopped {
+			return
+		}
+		log.Printf("[DEBUG] Starting instance %s after cold resize", instanceID)
+		if startErr := instanceV2Action(client, clientV2, instanceID, typesV2.InstanceActionTypeStart); startErr != nil {
+			err = errors.Join(err, fmt.Errorf("cannot start instance %s after cold resize: %w", instanceID, startErr))
+		}
+	}()
+
+	return true, resizeInstanceV2(client, instanceID, flavorID)
 }
 
 func sgUniqueIDs(i interface{}) int {
@@ -972,7 +1400,7 @@ func attachNewInterface(i interface{}, client *gcorecloud.ServiceClient, instanc
 		InterfaceOpts: instances.InterfaceOpts{
 			Name:     &ifaceName,
 			Type:     iType,
-			IPFamily: types.IPFamilyType(iface["ip_family"].(string)),
+			IPFamily: instanceInterfaceIPFamily(iface),
 		},
 	}
 
@@ -1016,3 +1444,335 @@ func waitInstanceOperation(client *gcorecloud.ServiceClient, taskID tasks.TaskID
 	})
 	return err
 }
+
+// readInstanceV2FlavorID sets flavor_id and actual_flavor_id from the flavor the instance runs on. flavor_id keeps
+// the configured value while the instance runs on the flavor recorded in actual_flavor_id on create, so an
+// alternative flavor of create_retry doesn't cause a resize. A flavor changed outside of Terraform is read back.
+func readInstanceV2FlavorID(d *schema.ResourceData, flavorID string) {
+	if flavorID != d.Get("actual_flavor_id").(string) {
+		d.Set("flavor_id", flavorID)
+	}
+	d.Set("actual_flavor_id", flavorID)
+}
+
+// errInstanceV2CreateFailed is returned when the creation task failed and left the instance in ERROR state,
+// the way scheduling failures like no free capacity for the flavor are reported.
+type errInstanceV2CreateFailed struct {
+	instanceID string
+	err        error
+}
+
+func (e errInstanceV2CreateFailed) Error() string {
+	return fmt.Sprintf("instance %s is in %s state after the failed creation: %s", e.instanceID, InstanceVMStateError, e.err)
+}
+
+func (e errInstanceV2CreateFailed) Unwrap() error {
+	return e.err
+}
+
+// isInstanceV2CapacityError reports whether the creation failed due to temporarily unavailable capacity:
+// the instance could not be scheduled or the API rejected the request with 429 or 503.
+func isInstanceV2CapacityError(err error) bool {
+	var failed errInstanceV2CreateFailed
+	if errors.As(err, &failed) {
+		return true
+	}
+	var codeErr gcorecloud.StatusCodeError
+	if errors.As(err, &codeErr) {
+		switch codeErr.GetStatusCode() {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		}
+	}
+	return false
+}
+
+// createInstanceV2 creates instance and waits for it. The instance left in ERROR state by a failed creation is
+// reported with errInstanceV2CreateFailed.
+func createInstanceV2(clientv1, clientv2 *gcorecloud.ServiceClient, opts instances.CreateOpts) (string, error) {
+	results, err := instances.Create(clientv2, opts).Extract()
+	if err != nil {
+		return "", err
+	}
+
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	InstanceID, err := tasks.WaitTaskAndReturnResult(clientv1, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(clientv1, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		Instance, err := instances.ExtractInstanceIDFromTask(taskInfo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve Instance ID from task info: %w", err)
+		}
+		return Instance, nil
+	},
+	)
+	if err != nil {
+		taskInfo, taskErr := tasks.Get(clientv1, string(taskID)).Extract()
+		if taskErr != nil {
+			return "", err
+		}
+		failedID, idErr := instances.ExtractInstanceIDFromTask(taskInfo)
+		if idErr != nil {
+			return "", err
+		}
+		if instance, getErr := instances.Get(clientv1, failedID).Extract(); getErr == nil && instance.VMState == InstanceVMStateError {
+			return "", errInstanceV2CreateFailed{instanceID: failedID, err: err}
+		}
+		return "", err
+	}
+	return InstanceID.(string), nil
+}
+
+// deleteFailedInstanceV2 deletes the instance left in ERROR state by a failed creation together with its volumes
+// so that the next attempt doesn't leak it.
+func deleteFailedInstanceV2(client *gcorecloud.ServiceClient, instanceID string) error {
+	instance, err := instances.Get(client, instanceID).Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			return nil
+		default:
+			return err
+		}
+	}
+	if instance.VMState != InstanceVMStateError {
+		return fmt.Errorf("instance %s is left in %s state by the failed creation", instanceID, instance.VMState)
+	}
+
+	var delOpts instances.DeleteOpts
+	for _, vol := range instance.Volumes {
+		if vol.DeleteOnTermination {
+			delOpts.Volumes = append(delOpts.Volumes, vol.ID)
+		}
+	}
+	log.Printf("[DEBUG] Delete instance %s in ERROR state before retrying", instanceID)
+	return deleteInstance(client, instanceID, delOpts)
+}
+
+// createInstanceV2WithRetry creates instance retrying capacity errors according to create_retry policy and returns
+// its ID with the flavor used. Every flavor is tried max_attempts times, starting with flavor from opts and followed
+// by alternatives. Retries stop when the timeout expires.
+func createInstanceV2WithRetry(ctx context.Context, clientv1, clientv2 *gcorecloud.ServiceClient, opts instances.CreateOpts, createRetry []interface{}, timeout time.Duration) (string, string, error) {
+	if len(createRetry) == 0 || createRetry[0] == nil {
+		instanceID, err := createInstanceV2(clientv1, clientv2, opts)
+		if err != nil {
+			return "", "", err
+		}
+		return instanceID, opts.Flavor, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	policy := createRetry[0].(map[string]interface{})
+	maxAttempts := policy["max_attempts"].(int)
+	backoff := time.Duration(policy["backoff"].(int)) * time.Second
+	flavors := []string{opts.Flavor}
+	for _, f := range policy["alternative_flavor_ids"].([]interface{}) {
+		flavors = append(flavors, f.(string))
+	}
+
+	var lastErr error
+	delay := backoff
+	for _, flavor := range flavors {
+		opts.Flavor = flavor
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			if lastErr != nil {
+				log.Printf("[DEBUG] Retry instance creating with flavor %s in %s, attempt %d", flavor, delay, attempt)
+				select {
+				case <-ctx.Done():
+					return "", "", fmt.Errorf("cannot create instance before timeout: %w", lastErr)
+				case <-time.After(delay):
+				}
+				delay = nextInstanceV2CreateBackoff(delay)
+			}
+			instanceID, err := createInstanceV2(clientv1, clientv2, opts)
+			if err == nil {
+				return instanceID, flavor, nil
+			}
+			if !isInstanceV2CapacityError(err) {
+				return "", "", err
+			}
+			log.Printf("[DEBUG] Instance creating with flavor %s failed due to capacity: %s", flavor, err)
+			lastErr = err
+			var failed errInstanceV2CreateFailed
+			if errors.As(err, &failed) {
+				if err := deleteFailedInstanceV2(clientv1, failed.instanceID); err != nil {
+					return "", "", fmt.Errorf("cannot clean up instance %s before retrying: %w", failed.instanceID, err)
+				}
+			}
+		}
+	}
+	return "", "", fmt.Errorf("cannot create instance after retrying flavors %v: %w", flavors, lastErr)
+}
+
+func nextInstanceV2CreateBackoff(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay > instanceV2CreateMaxBackoff {
+		return instanceV2CreateMaxBackoff
+	}
+	return delay
+}
+
+// instanceV2InterfaceIPFamily returns ip family of the interface by the addresses assigned to it
+func instanceV2InterfaceIPFamily(assignments []instances.PortIP) types.IPFamilyType {
+	var hasIPv4, hasIPv6 bool
+	for _, assignment := range assignments {
+		if assignment.IPAddress.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+	switch {
+	case hasIPv4 && hasIPv6:
+		return types.DualStackIPFamilyType
+	case hasIPv6:
+		return types.IPv6IPFamilyType
+	default:
+		return types.IPv4IPFamilyType
+	}
+}
+
+// instanceV2PrimaryAddresses picks one address per IP family. Floating and public addresses win over
+// private fixed ones, ties are broken by network name and then by address order within the network.
+func instanceV2PrimaryAddresses(addresses map[string][]instances.InstanceAddress) (ipv4 string, ipv6 string) {
+	networks := make([]string, 0, len(addresses))
+	for name := range addresses {
+		networks = append(networks, name)
+	}
+	sort.Strings(networks)
+
+	var firstV4, firstV6 string
+	for _, name := range networks {
+		for _, iaddr := range addresses[name] {
+			ip := iaddr.Address
+			if ip == nil {
+				continue
+			}
+			external := iaddr.Type.String() == "floating" || (ip.IsGlobalUnicast() && !ip.IsPrivate())
+			if ip.To4() != nil {
+				if firstV4 == "" {
+					firstV4 = ip.String()
+				}
+				if external && ipv4 == "" {
+					ipv4 = ip.String()
+				}
+			} else {
+				if firstV6 == "" {
+					firstV6 = ip.String()
+				}
+				if external && ipv6 == "" {
+					ipv6 = ip.String()
+				}
+			}
+		}
+	}
+	if ipv4 == "" {
+		ipv4 = firstV4
+	}
+	if ipv6 == "" {
+		ipv6 = firstV6
+	}
+	return ipv4, ipv6
+}
+
+// instanceV2InterfaceAssignments drops IPv6 assignments of a dual stack interface and returns the first of them
+// separately. Single stack interfaces are returned as is.
+func instanceV2InterfaceAssignments(assignments []instances.PortIP) ([]instances.PortIP, string) {
+	var ipv4, ipv6 []instances.PortIP
+	for _, assignment := range assignments {
+		if assignment.IPAddress.To4() != nil {
+			ipv4 = append(ipv4, assignment)
+		} else {
+			ipv6 = append(ipv6, assignment)
+		}
+	}
+	if len(ipv4) == 0 || len(ipv6) == 0 {
+		return assignments, ""
+	}
+	return ipv4, ipv6[0].IPAddress.String()
+}
+
+// setupInstanceV2AppPortsSecurityGroup finds the security group created by the API for the application ports
+// and applies the configured name and description to it.
+func setupInstanceV2AppPortsSecurityGroup(d *schema.ResourceData, provider *gcorecloud.ProviderClient, client *gcorecloud.ServiceClient, instanceID string, ifs []interface{}) error {
+	ports, err := instances.ListPortsAll(client, instanceID)
+	if err != nil {
+		return err
+	}
+
+	sg, ok := findInstanceV2AppPortsSecurityGroup(ports, ifs)
+	if !ok {
+		log.Printf("[WARN] Cannot find application ports security group of instance %s", instanceID)
+		return nil
+	}
+	d.Set("app_ports_security_group_id", sg.ID)
+
+	if _, ok := d.GetOk("app_ports_security_group_name"); !ok {
+		return nil
+	}
+
+	clientSg, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+
+	return updateInstanceV2AppPortsSecurityGroup(clientSg, sg.ID, d)
+}
+
+// findInstanceV2AppPortsSecurityGroup returns the security group attached to the instance ports
+// that is neither configured on any interface nor the project default one.
+func findInstanceV2AppPortsSecurityGroup(ports []instances.InstancePorts, ifs []interface{}) (gcorecloud.ItemIDName, bool) {
+	configured := make(map[string]bool)
+	for _, raw := range ifs {
+		iface := raw.(map[string]interface{})
+		if sgs, ok := iface["security_groups"].(*schema.Set); ok {
+			for _, sg := range sgs.List() {
+				configured[sg.(string)] = true
+			}
+		}
+	}
+
+	for _, port := range ports {
+		for _, sg := range port.SecurityGroups {
+			if !configured[sg.ID] && sg.Name != "default" {
+				return sg, true
+			}
+		}
+	}
+
+	return gcorecloud.ItemIDName{}, false
+}
+
+// readInstanceV2AppPortsSecurityGroup returns the known application ports security group while it is
+// attached to the instance ports, otherwise looks it up again.
+func readInstanceV2AppPortsSecurityGroup(ports []instances.InstancePorts, ifs []interface{}, known string) string {
+	if known != "" {
+		for _, port := range ports {
+			for _, sg := range port.SecurityGroups {
+				if sg.ID == known {
+					return known
+				}
+			}
+		}
+	}
+	if sg, ok := findInstanceV2AppPortsSecurityGroup(ports, ifs); ok {
+		return sg.ID
+	}
+	return ""
+}
+
+func updateInstanceV2AppPortsSecurityGroup(client *gcorecloud.ServiceClient, sgID string, d *schema.ResourceData) error {
+	opts := securitygroups.UpdateOpts{
+		Name: d.Get("app_ports_security_group_name").(string),
+	}
+	if _, err := securitygroups.Update(client, sgID, opts).Extract(); err != nil {
+		return fmt.Errorf("cannot update application ports security group %s: %w", sgID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInstanceV2KeptBootVolumes(t *testing.T) {
+	volume := func(id string, bootIndex, deleteOnTermination cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"volume_id":             cty.StringVal(id),
+			"boot_index":            bootIndex,
+			"delete_on_termination": deleteOnTermination,
+		})
+	}
+	config := cty.ObjectVal(map[string]cty.Value{
+		"volume": cty.SetVal([]cty.Value{
+			volume("boot-kept", cty.NumberIntVal(0), cty.False),
+			volume("boot-deleted", cty.NumberIntVal(0), cty.True),
+			volume("boot-default", cty.NumberIntVal(0), cty.NullVal(cty.Bool)),
+			volume("data-kept", cty.NumberIntVal(1), cty.False),
+			volume("data-default", cty.NullVal(cty.Number), cty.False),
+		}),
+	})
+
+	got := instanceV2KeptBootVolumes(config)
+	if want := []string{"boot-kept"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("instanceV2KeptBootVolumes() = %v, want %v", got, want)
+	}
+	if got := instanceV2KeptBootVolumes(cty.NullVal(cty.DynamicPseudoType)); got != nil {
+		t.Errorf("instanceV2KeptBootVolumes() = %v, want nil", got)
+	}
+}
+
+func TestReadInstanceV2DeleteOnTermination(t *testing.T) {
+	volume := func(id string, deleteOnTermination cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"volume_id":             cty.StringVal(id),
+			"delete_on_termination": deleteOnTermination,
+		})
+	}
+	// the volume is attached with delete_on_termination=true, the API keeps it after the update
+	attached := instances.InstanceVolume{ID: "data", DeleteOnTermination: true}
+	state := map[string]map[string]interface{}{
+		"data": {"volume_id": "data", "delete_on_termination": false},
+	}
+
+	tests := []struct {
+		name   string
+		config cty.Value
+		want   bool
+	}{
+		{
+			name:   "update to false",
+			config: cty.ObjectVal(map[string]cty.Value{"volume": cty.SetVal([]cty.Value{volume("data", cty.False)})}),
+			want:   false,
+		},
+		{
+			name:   "not configured",
+			config: cty.ObjectVal(map[string]cty.Value{"volume": cty.SetVal([]cty.Value{volume("data", cty.NullVal(cty.Bool))})}),
+			want:   true,
+		},
+		{
+			name:   "refresh keeps state",
+			config: cty.NullVal(cty.Object(map[string]cty.Type{"volume": cty.Set(cty.Object(map[string]cty.Type{"volume_id": cty.String, "delete_on_termination": cty.Bool}))})),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readInstanceV2DeleteOnTermination(tt.config, state, attached); got != tt.want {
+				t.Errorf("readInstanceV2DeleteOnTermination() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := readInstanceV2DeleteOnTermination(cty.NullVal(cty.DynamicPseudoType), nil, attached); !got {
+		t.Errorf("readInstanceV2DeleteOnTermination() = %v, want API value on import", got)
+	}
+
+	// the kept value is applied on the instance deletion
+	toDelete, toDetach := instanceV2TerminationVolumes([]instances.InstanceVolume{attached}, []interface{}{state["data"]})
+	if len(toDelete) != 0 || !reflect.DeepEqual(toDetach, []string{"data"}) {
+		t.Errorf("instanceV2TerminationVolumes() = %v, %v, want [], [data]", toDelete, toDetach)
+	}
+}
+
+func TestReadInstanceV2FlavorID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceInstanceV2().Schema, map[string]interface{}{"flavor_id": "g1-standard-2-4"})
+
+	// created with an alternative flavor of create_retry, flavor_id keeps the configured value
+	d.Set("actual_flavor_id", "g2-standard-2-4")
+	readInstanceV2FlavorID(d, "g2-standard-2-4")
+	if got := d.Get("flavor_id"); got != "g1-standard-2-4" {
+		t.Errorf("flavor_id = %v, want configured g1-standard-2-4", got)
+	}
+
+	// resized outside of Terraform, the change is read back
+	readInstanceV2FlavorID(d, "g1-standard-4-8")
+	if got := d.Get("flavor_id"); got != "g1-standard-4-8" {
+		t.Errorf("flavor_id = %v, want g1-standard-4-8", got)
+	}
+	if got := d.Get("actual_flavor_id"); got != "g1-standard-4-8" {
+		t.Errorf("actual_flavor_id = %v, want g1-standard-4-8", got)
+	}
+
+	// import has no actual_flavor_id yet
+	imported := schema.TestResourceDataRaw(t, resourceInstanceV2().Schema, map[string]interface{}{})
+	readInstanceV2FlavorID(imported, "g1-standard-2-4")
+	if imported.Get("flavor_id") != "g1-standard-2-4" || imported.Get("actual_flavor_id") != "g1-standard-2-4" {
+		t.Errorf("flavor_id = %v, actual_flavor_id = %v, want g1-standard-2-4", imported.Get("flavor_id"), imported.Get("actual_flavor_id"))
+	}
+}
+
+func TestIsInstanceV2CapacityError(t *testing.T) {
+	taskErr := errors.New("task is in error state: ERROR. Error: No valid host was found")
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"instance in error state", errInstanceV2CreateFailed{instanceID: "instance1", err: taskErr}, true},
+		{"wrapped instance in error state", fmt.Errorf("create: %w", errInstanceV2CreateFailed{instanceID: "instance1", err: taskErr}), true},
+		{"503", gcorecloud.ErrDefault503{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{Actual: 503}}, true},
+		{"429", gcorecloud.ErrDefault429{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{Actual: 429}}, true},
+		{"400", gcorecloud.ErrDefault400{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{Actual: 400}}, false},
+		{"task error without instance", taskErr, false},
+	}
+	for _, c := range cases {
+		if got := isInstanceV2CapacityError(c.err); got != c.want {
+			t.Errorf("%s: isInstanceV2CapacityError() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
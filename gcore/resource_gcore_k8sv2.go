@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -39,10 +40,11 @@ func resourceK8sV2() *schema.Resource {
 		ReadContext:   resourceK8sV2Read,
 		UpdateContext: resourceK8sV2Update,
 		DeleteContext: resourceK8sV2Delete,
-		Description:   "Represent k8s cluster with one default pool.",
+		Description:   "Represent k8s cluster with one default pool. Pools managed by gcore_k8sv2_pool resources are not part of the pool list.",
 		Timeouts: &schema.ResourceTimeout{
 			Create: &k8sCreateTimeout,
 			Update: &k8sCreateTimeout,
+			Delete: &k8sCreateTimeout,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -418,11 +420,41 @@ func resourceK8sV2() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"max_pods_per_node": {
+							Type:         schema.TypeInt,
+							Description:  "Maximum number of pods per pool node, sets maxPods of the kubelet configuration. Changing the value of this attribute will trigger recreation of the cluster pool.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(1, 250),
+						},
+						"kube_reserved": {
+							Type:        schema.TypeMap,
+							Description: "Resources reserved for kubernetes system daemons on pool nodes, e.g. cpu = \"200m\", memory = \"1Gi\". It is sent as kubeReserved of kubelet_config in the kubelet flag format, e.g. \"cpu=200m,memory=1Gi\". Changing the value of this attribute will trigger recreation of the cluster pool.",
+							Optional:    true,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"system_reserved": {
+							Type:        schema.TypeMap,
+							Description: "Resources reserved for OS system daemons on pool nodes, e.g. cpu = \"200m\", memory = \"1Gi\". It is sent as systemReserved of kubelet_config in the kubelet flag format, e.g. \"cpu=200m,memory=1Gi\". Changing the value of this attribute will trigger recreation of the cluster pool.",
+							Optional:    true,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 						"status": {
 							Type:        schema.TypeString,
 							Description: "Cluster pool status.",
 							Computed:    true,
 						},
+						"task_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the last task that created the cluster pool, either cluster or pool creation task.",
+							Computed:    true,
+						},
 						"servergroup_name": {
 							Type:        schema.TypeString,
 							Description: "Server group name",
@@ -441,6 +473,12 @@ func resourceK8sV2() *schema.Resource {
 					},
 				},
 			},
+			"separate_pools": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Names of the pools managed by gcore_k8sv2_pool resources, the cluster neither reads nor deletes them. Pools created by gcore_k8sv2_pool are recognized by their label, imported pools must be listed here.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"security_group_rules": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -521,6 +559,22 @@ func resourceK8sV2() *schema.Resource {
 				Computed:    true,
 				Description: "Security group ID.",
 			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Raw kubeconfig of the cluster.",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kubernetes API server URL of the cluster.",
+			},
+			"certificate_authority_data": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64 encoded CA certificate of the Kubernetes API server.",
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Description: "Cluster status.",
@@ -711,11 +765,9 @@ func resourceK8sV2Create(ctx context.Context, d *schema.ResourceData, m interfac
 				poolOpts.CrioConfig[k] = v.(string)
 			}
 		}
-		if kubeletCfg, ok := pool["kubelet_config"].(map[string]interface{}); ok {
-			poolOpts.KubeletConfig = map[string]string{}
-			for k, v := range kubeletCfg {
-				poolOpts.KubeletConfig[k] = v.(string)
-			}
+		poolOpts.KubeletConfig, err = resourceK8sV2PoolKubeletConfig(pool)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 		opts.Pools = append(opts.Pools, poolOpts)
 	}
@@ -768,6 +820,12 @@ func resourceK8sV2Create(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
+	poolTasks := map[string]string{}
+	for _, pool := range opts.Pools {
+		poolTasks[pool.Name] = string(taskID)
+	}
+	resourceK8sV2SetPoolTaskIDs(d, poolTasks)
+
 	resourceK8sV2Read(ctx, d, m)
 	log.Printf("[DEBUG] Finish k8s cluster creating (%s)", clusterName)
 	return diags
@@ -786,7 +844,7 @@ func getSuitableSecurityGroup(sgs []securitygroups.SecurityGroup, name string, p
 func filterSecurityGroupRules(rules []securitygroups.SecurityGroupRule) []securitygroups.SecurityGroupRule {
 	newRules := []securitygroups.SecurityGroupRule{}
 	for _, rule := range rules {
-		if *rule.Description == "system" {
+		if rule.Description != nil && *rule.Description == "system" {
 			continue
 		}
 		newRules = append(newRules, rule)
@@ -891,12 +949,21 @@ func resourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interface{
 
 	// Returned pool order needs to match TF state or users will see broken diff,
 	// so we first process all pools stored in the state file, and then append any remaining pools.
+	separatePools := d.Get("separate_pools").(*schema.Set)
 	var poolData []interface{}
 	for _, rawPool := range d.Get("pool").([]interface{}) {
 		pool := rawPool.(map[string]interface{})
 		poolName := pool["name"].(string)
+		if separatePools.Contains(poolName) {
+			log.Printf("[DEBUG] Skipping cluster pool %q managed separately\n", poolName)
+			delete(poolMap, poolName)
+			continue
+		}
 		if p, ok := poolMap[poolName]; ok {
-			poolData = append(poolData, resourceK8sV2PoolDataFromPool(p))
+			data := resourceK8sV2PoolDataFromPool(p, pool).(map[string]interface{})
+			// pool tasks are not returned by API, so keep the ones recorded by the provider
+			data["task_id"] = pool["task_id"]
+			poolData = append(poolData, data)
 			delete(poolMap, poolName)
 		} else {
 			// prevent breaking diff when a pool from state file is missing
@@ -905,12 +972,23 @@ func resourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interface{
 		}
 	}
 	for _, pool := range poolMap {
-		poolData = append(poolData, resourceK8sV2PoolDataFromPool(pool))
+		if separatePools.Contains(pool.Name) || pool.Labels[k8sV2PoolManagedLabel] == k8sV2PoolManagedLabelValue {
+			log.Printf("[DEBUG] Skipping cluster pool %q managed by %s\n", pool.Name, k8sV2PoolManagedLabelValue)
+			continue
+		}
+		poolData = append(poolData, resourceK8sV2PoolDataFromPool(pool, nil))
 	}
 	if err := d.Set("pool", poolData); err != nil {
 		return diag.FromErr(err)
 	}
 
+	// kubeconfig isn't available until the cluster is provisioned, keep the previous one then
+	if kubeconfig, err := clusters.GetConfig(client, clusterName).Extract(); err != nil {
+		log.Printf("[WARN] Cannot get kubeconfig of cluster %s: %s", clusterName, err)
+	} else if err := resourceK8sV2SetKubeconfig(d, kubeconfig.Config); err != nil {
+		log.Printf("[WARN] Cannot parse kubeconfig of cluster %s: %s", clusterName, err)
+	}
+
 	// get cluster's security group
 	sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
 	if err != nil {
@@ -977,11 +1055,18 @@ func resourceK8sV2Update(ctx context.Context, d *schema.ResourceData, m interfac
 			return diag.FromErr(err)
 		}
 
+		poolTasks := map[string]string{}
+
 		// Any new pools must be created first, so that "replace" can safely delete pools that it will recreate.
 		// This also covers pools that were renamed, because pool name must be unique.
 		for _, pool := range new {
 			if resourceK8sV2FindClusterPool(old, pool) == nil {
-				if err := resourceK8sV2CreateClusterPool(client, tasksClient, clusterName, pool); err != nil {
+				taskID, err := resourceK8sV2CreateClusterPool(client, tasksClient, clusterName, pool)
+				if taskID != "" {
+					poolTasks[pool.(map[string]interface{})["name"].(string)] = taskID
+				}
+				if err != nil {
+					resourceK8sV2SetPoolTaskIDs(d, poolTasks)
 					return diag.FromErr(err)
 				}
 			}
@@ -997,7 +1082,12 @@ func resourceK8sV2Update(ctx context.Context, d *schema.ResourceData, m interfac
 				if err := resourceK8sV2DeleteClusterPool(client, tasksClient, clusterName, pool); err != nil {
 					return diag.FromErr(err)
 				}
-				if err := resourceK8sV2CreateClusterPool(client, tasksClient, clusterName, pool); err != nil {
+				taskID, err := resourceK8sV2CreateClusterPool(client, tasksClient, clusterName, pool)
+				if taskID != "" {
+					poolTasks[pool.(map[string]interface{})["name"].(string)] = taskID
+				}
+				if err != nil {
+					resourceK8sV2SetPoolTaskIDs(d, poolTasks)
 					return diag.FromErr(err)
 				}
 			} else if resourceK8sV2ClusterPoolNeedsUpdate(old, pool) {
@@ -1009,13 +1099,24 @@ func resourceK8sV2Update(ctx context.Context, d *schema.ResourceData, m interfac
 
 		// Finish up by removing all pools that need to be deleted (explicit deletes and leftovers from renames).
 		// This allows us to have replace working in case we are going down to 1 pool.
+		// Pools taken over by gcore_k8sv2_pool are left to it, eg. when the state wasn't refreshed after the import.
+		separatePools := d.Get("separate_pools").(*schema.Set)
 		for _, pool := range old {
 			if resourceK8sV2FindClusterPool(new, pool) == nil {
+				managedSeparately, err := resourceK8sV2ClusterPoolManagedSeparately(client, clusterName, separatePools, pool)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				if managedSeparately {
+					continue
+				}
 				if err := resourceK8sV2DeleteClusterPool(client, tasksClient, clusterName, pool); err != nil {
 					return diag.FromErr(err)
 				}
 			}
 		}
+
+		resourceK8sV2SetPoolTaskIDs(d, poolTasks)
 	}
 
 	if d.HasChange("security_group_rules") {
@@ -1208,7 +1309,8 @@ func resourceK8sV2Delete(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	_, err = tasks.WaitTaskAndReturnResult(tasksClient, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+	timeout := int(d.Timeout(schema.TimeoutDelete).Seconds())
+	_, err = tasks.WaitTaskAndReturnResult(tasksClient, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
 		_, err := clusters.Get(client, clusterName).Extract()
 		if err == nil {
 			return nil, fmt.Errorf("cannot delete k8s cluster with name: %s", clusterName)
@@ -1295,10 +1397,20 @@ func resourceK8sV2ClusterPoolNeedsReplace(list []interface{}, pool interface{})
 	if !reflect.DeepEqual(old["kubelet_config"], new["kubelet_config"]) {
 		return true
 	}
+	if old["max_pods_per_node"] != new["max_pods_per_node"] {
+		return true
+	}
+	if !reflect.DeepEqual(old["kube_reserved"], new["kube_reserved"]) {
+		return true
+	}
+	if !reflect.DeepEqual(old["system_reserved"], new["system_reserved"]) {
+		return true
+	}
 	return false
 }
 
-func resourceK8sV2CreateClusterPool(client, tasksClient *gcorecloud.ServiceClient, clusterName string, data interface{}) error {
+// resourceK8sV2CreateClusterPool creates the pool and returns the ID of its creation task, also when the task failed.
+func resourceK8sV2CreateClusterPool(client, tasksClient *gcorecloud.ServiceClient, clusterName string, data interface{}) (string, error) {
 	pool := data.(map[string]interface{})
 	poolName := pool["name"].(string)
 	log.Printf("[DEBUG] Creating cluster pool (%s)", poolName)
@@ -1332,15 +1444,14 @@ func resourceK8sV2CreateClusterPool(client, tasksClient *gcorecloud.ServiceClien
 			opts.CrioConfig[k] = v.(string)
 		}
 	}
-	if kubeletCfg, ok := pool["kubelet_config"].(map[string]interface{}); ok {
-		opts.KubeletConfig = map[string]string{}
-		for k, v := range kubeletCfg {
-			opts.KubeletConfig[k] = v.(string)
-		}
+	kubeletConfig, err := resourceK8sV2PoolKubeletConfig(pool)
+	if err != nil {
+		return "", err
 	}
+	opts.KubeletConfig = kubeletConfig
 	results, err := pools.Create(client, clusterName, opts).Extract()
 	if err != nil {
-		return fmt.Errorf("create cluster pool: %w", err)
+		return "", fmt.Errorf("create cluster pool: %w", err)
 	}
 
 	taskID := results.Tasks[0]
@@ -1349,13 +1460,42 @@ func resourceK8sV2CreateClusterPool(client, tasksClient *gcorecloud.ServiceClien
 		return nil, nil
 	})
 	if err != nil {
-		return fmt.Errorf("wait for task %s: %w", taskID, err)
+		// the failed task is returned as well, so it can be looked up in the pool task_id
+		return string(taskID), fmt.Errorf("wait for task %s: %w", taskID, err)
 	}
 
 	log.Printf("[DEBUG] Created cluster pool (%s)", poolName)
+	return string(taskID), nil
+}
+
+func resourceK8sV2SetKubeconfig(d *schema.ResourceData, kubeconfig string) error {
+	d.Set("kubeconfig", kubeconfig)
+	cfg, err := parseK8sKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	d.Set("endpoint", cfg.Server)
+	d.Set("certificate_authority_data", cfg.CertificateAuthorityData)
 	return nil
 }
 
+// resourceK8sV2SetPoolTaskIDs records task IDs of the pool operations, keyed by pool name, into pool state
+func resourceK8sV2SetPoolTaskIDs(d *schema.ResourceData, poolTasks map[string]string) {
+	if len(poolTasks) == 0 {
+		return
+	}
+	poolData := d.Get("pool").([]interface{})
+	for _, rawPool := range poolData {
+		pool := rawPool.(map[string]interface{})
+		if taskID, ok := poolTasks[pool["name"].(string)]; ok {
+			pool["task_id"] = taskID
+		}
+	}
+	if err := d.Set("pool", poolData); err != nil {
+		log.Printf("[WARN] Cannot set cluster pool task ids: %v", err)
+	}
+}
+
 func resourceK8sV2DeleteClusterPool(client, tasksClient *gcorecloud.ServiceClient, clusterName string, data interface{}) error {
 	pool, ok := data.(map[string]interface{})
 	if !ok {
@@ -1390,6 +1530,30 @@ func resourceK8sV2DeleteClusterPool(client, tasksClient *gcorecloud.ServiceClien
 	return nil
 }
 
+// resourceK8sV2ClusterPoolManagedSeparately reports whether the pool is listed in separate_pools
+// or marked as created by gcore_k8sv2_pool.
+func resourceK8sV2ClusterPoolManagedSeparately(client *gcorecloud.ServiceClient, clusterName string, separatePools *schema.Set, data interface{}) (bool, error) {
+	poolName := data.(map[string]interface{})["name"].(string)
+	if separatePools.Contains(poolName) {
+		log.Printf("[DEBUG] Skipping deletion of cluster pool %q managed separately\n", poolName)
+		return true, nil
+	}
+	pool, err := pools.Get(client, clusterName, poolName).Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			return false, nil
+		default:
+			return false, fmt.Errorf("get cluster pool %s: %w", poolName, err)
+		}
+	}
+	if pool.Labels[k8sV2PoolManagedLabel] != k8sV2PoolManagedLabelValue {
+		return false, nil
+	}
+	log.Printf("[DEBUG] Skipping deletion of cluster pool %q managed by %s\n", poolName, k8sV2PoolManagedLabelValue)
+	return true, nil
+}
+
 func resourceK8sV2UpdateClusterPool(client *gcorecloud.ServiceClient, clusterName string, data interface{}) error {
 	pool := data.(map[string]interface{})
 	poolName := pool["name"].(string)
@@ -1425,8 +1589,10 @@ func resourceK8sV2UpdateClusterPool(client *gcorecloud.ServiceClient, clusterNam
 	return nil
 }
 
-func resourceK8sV2PoolDataFromPool(pool pools.ClusterPool) interface{} {
-	return map[string]interface{}{
+// resourceK8sV2PoolDataFromPool returns pool data, current pool data from state decides on kubelet config keys
+// kept in kubelet_config
+func resourceK8sV2PoolDataFromPool(pool pools.ClusterPool, current map[string]interface{}) interface{} {
+	data := map[string]interface{}{
 		"name":                 pool.Name,
 		"flavor_id":            pool.FlavorID,
 		"min_node_count":       pool.MinNodeCount,
@@ -1439,20 +1605,21 @@ func resourceK8sV2PoolDataFromPool(pool pools.ClusterPool) interface{} {
 		"labels":               resourceK8sV2FilteredPoolLabels(pool.Labels),
 		"taints":               pool.Taints,
 		"crio_config":          pool.CrioConfig,
-		"kubelet_config":       pool.KubeletConfig,
 		"servergroup_policy":   pool.ServerGroupPolicy,
 		"servergroup_name":     pool.ServerGroupName,
 		"servergroup_id":       pool.ServerGroupID,
 		"status":               pool.Status,
 		"created_at":           pool.CreatedAt.Format(time.RFC850),
 	}
+	resourceK8sV2PoolKubeletData(current, data, pool.KubeletConfig)
+	return data
 }
 
 func resourceK8sV2FilteredPoolLabels(labels map[string]string) map[string]string {
 	result := map[string]string{}
 	for k, v := range labels {
 		// filter out system labels to hide them from state file and diffs
-		if strings.HasPrefix(k, "gcorecluster.x-k8s.io") {
+		if strings.HasPrefix(k, "gcorecluster.x-k8s.io") || k == k8sV2PoolManagedLabel {
 			continue
 		}
 		result[k] = v
@@ -0,0 +1,395 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/pools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// k8sV2PoolManagedLabel marks pools managed by gcore_k8sv2_pool, gcore_k8sv2 ignores them
+	k8sV2PoolManagedLabel      = "terraform.gcore.com/resource"
+	k8sV2PoolManagedLabelValue = "gcore_k8sv2_pool"
+)
+
+func resourceK8sV2Pool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceK8sV2PoolCreate,
+		ReadContext:   resourceK8sV2PoolRead,
+		UpdateContext: resourceK8sV2PoolUpdate,
+		DeleteContext: resourceK8sV2PoolDelete,
+		Description:   "Represent k8s cluster pool managed separately from the cluster. The pool must not be declared in the pool list of gcore_k8sv2 as well. An imported pool must be listed in separate_pools of gcore_k8sv2.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: &k8sCreateTimeout,
+			Update: &k8sCreateTimeout,
+			Delete: &k8sCreateTimeout,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, clusterName, poolName, err := ImportStringParserExtended(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("cluster_name", clusterName)
+				d.Set("name", poolName)
+				d.SetId(poolName)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the cluster the pool belongs to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Cluster pool name.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"flavor_id": {
+				Type:        schema.TypeString,
+				Description: "Cluster pool node flavor ID. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"min_node_count": {
+				Type:        schema.TypeInt,
+				Description: "Minimum number of nodes in the cluster pool.",
+				Required:    true,
+			},
+			"max_node_count": {
+				Type:        schema.TypeInt,
+				Description: "Maximum number of nodes in the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"node_count": {
+				Type:        schema.TypeInt,
+				Description: "Current node count in the cluster pool.",
+				Computed:    true,
+			},
+			"servergroup_policy": {
+				Type:        schema.TypeString,
+				Description: "Server group policy: anti-affinity, soft-anti-affinity or affinity. Required for VM flavors and not allowed for baremetal ones. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"boot_volume_type": {
+				Type:        schema.TypeString,
+				Description: "Cluster pool boot volume type. Must be set only for VM pools. Available values are 'standard', 'ssd_hiiops', 'cold', 'ultra'. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"boot_volume_size": {
+				Type:        schema.TypeInt,
+				Description: "Cluster pool boot volume size. Must be set only for VM pools. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"auto_healing_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable/disable auto healing of cluster pool nodes.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"is_public_ipv4": {
+				Type:        schema.TypeBool,
+				Description: "Assign public IPv4 address to nodes in this pool. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Description: "Labels applied to the cluster pool nodes.",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"taints": {
+				Type:        schema.TypeMap,
+				Description: "Taints applied to the cluster pool nodes.",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"crio_config": {
+				Type:        schema.TypeMap,
+				Description: "Crio configuration for pool nodes. Keys and values are expected to follow the crio option format. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"kubelet_config": {
+				Type:        schema.TypeMap,
+				Description: "Kubelet configuration for pool nodes. Keys and values are expected to follow the kubelet configuration file format. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_pods_per_node": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of pods per pool node, sets maxPods of the kubelet configuration. Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 250),
+			},
+			"kube_reserved": {
+				Type:        schema.TypeMap,
+				Description: "Resources reserved for kubernetes system daemons on pool nodes, e.g. cpu = \"200m\", memory = \"1Gi\". It is sent as kubeReserved of kubelet_config in the kubelet flag format, e.g. \"cpu=200m,memory=1Gi\". Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"system_reserved": {
+				Type:        schema.TypeMap,
+				Description: "Resources reserved for OS system daemons on pool nodes, e.g. cpu = \"200m\", memory = \"1Gi\". It is sent as systemReserved of kubelet_config in the kubelet flag format, e.g. \"cpu=200m,memory=1Gi\". Changing the value of this attribute will trigger recreation of the cluster pool.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Cluster pool status.",
+				Computed:    true,
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the task that created the cluster pool.",
+				Computed:    true,
+			},
+			"servergroup_name": {
+				Type:        schema.TypeString,
+				Description: "Server group name",
+				Computed:    true,
+			},
+			"servergroup_id": {
+				Type:        schema.TypeString,
+				Description: "Server group id",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "Cluster pool creation date.",
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: resourceK8sV2PoolDiff,
+	}
+}
+
+// resourceK8sV2PoolDiff validates the server group policy of the pool flavor.
+func resourceK8sV2PoolDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("flavor_id") || !diff.NewValueKnown("servergroup_policy") {
+		return nil
+	}
+	flavorID, policy := diff.Get("flavor_id").(string), diff.Get("servergroup_policy").(string)
+	if resourceK8sV2IsVMFlavor(flavorID) && policy == "" {
+		return fmt.Errorf("servergroup_policy is required for flavor %v", flavorID)
+	}
+	if !resourceK8sV2IsVMFlavor(flavorID) && policy != "" {
+		return fmt.Errorf("servergroup_policy cannot be set for flavor %v", flavorID)
+	}
+	return nil
+}
+
+func resourceK8sV2PoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start k8s cluster pool creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, K8sPoint, versionPointV2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tasksClient, err := CreateClient(provider, d, tasksPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	taskID, err := resourceK8sV2CreateClusterPool(client, tasksClient, d.Get("cluster_name").(string), resourceK8sV2PoolData(d))
+	if taskID != "" {
+		// the pool exists once its task is started, a failed one is kept tainted with the task to look into
+		d.SetId(d.Get("name").(string))
+		d.Set("task_id", taskID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish k8s cluster pool creating (%s)", d.Id())
+	return resourceK8sV2PoolRead(ctx, d, m)
+}
+
+func resourceK8sV2PoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start k8s cluster pool reading (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, K8sPoint, versionPointV2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pool, err := pools.Get(client, d.Get("cluster_name").(string), d.Id()).Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			log.Printf("[WARN] Removing k8s cluster pool %s because resource doesn't exist anymore", d.Id())
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	for k, v := range resourceK8sV2PoolDataFromPool(*pool, map[string]interface{}{
+		"kubelet_config": d.Get("kubelet_config"),
+	}).(map[string]interface{}) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish k8s cluster pool reading")
+	return nil
+}
+
+func resourceK8sV2PoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start k8s cluster pool updating (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, K8sPoint, versionPointV2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("min_node_count", "max_node_count", "auto_healing_enabled", "labels", "taints") {
+		if err := resourceK8sV2UpdateClusterPool(client, d.Get("cluster_name").(string), resourceK8sV2PoolData(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish k8s cluster pool updating")
+	return resourceK8sV2PoolRead(ctx, d, m)
+}
+
+func resourceK8sV2PoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start k8s cluster pool deleting (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, K8sPoint, versionPointV2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tasksClient, err := CreateClient(provider, d, tasksPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := resourceK8sV2DeleteClusterPool(client, tasksClient, d.Get("cluster_name").(string), resourceK8sV2PoolData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish k8s cluster pool deleting")
+	return nil
+}
+
+// resourceK8sV2PoolData returns the pool in the format of gcore_k8sv2 pool list item,
+// the pool labels include the mark hiding the pool from gcore_k8sv2.
+func resourceK8sV2PoolData(d *schema.ResourceData) map[string]interface{} {
+	labels := map[string]interface{}{k8sV2PoolManagedLabel: k8sV2PoolManagedLabelValue}
+	for k, v := range d.Get("labels").(map[string]interface{}) {
+		labels[k] = v
+	}
+
+	return map[string]interface{}{
+		"name":                 d.Get("name").(string),
+		"flavor_id":            d.Get("flavor_id").(string),
+		"min_node_count":       d.Get("min_node_count").(int),
+		"max_node_count":       d.Get("max_node_count").(int),
+		"boot_volume_type":     d.Get("boot_volume_type").(string),
+		"boot_volume_size":     d.Get("boot_volume_size").(int),
+		"auto_healing_enabled": d.Get("auto_healing_enabled").(bool),
+		"servergroup_policy":   d.Get("servergroup_policy").(string),
+		"is_public_ipv4":       d.Get("is_public_ipv4").(bool),
+		"labels":               labels,
+		"taints":               d.Get("taints").(map[string]interface{}),
+		"crio_config":          d.Get("crio_config").(map[string]interface{}),
+		"kubelet_config":       d.Get("kubelet_config").(map[string]interface{}),
+		"max_pods_per_node":    d.Get("max_pods_per_node").(int),
+		"kube_reserved":        d.Get("kube_reserved").(map[string]interface{}),
+		"system_reserved":      d.Get("system_reserved").(map[string]interface{}),
+	}
+}
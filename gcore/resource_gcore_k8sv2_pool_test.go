@@ -0,0 +1,43 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestK8sV2PoolImport(t *testing.T) {
+	r := resourceK8sV2Pool()
+
+	// the importer gets no provider config, it must not call the API
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("1:76:cluster1:pool1")
+	result, err := r.Importer.StateContext(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	got := result[0]
+	if got.Id() != "pool1" {
+		t.Errorf("id = %q, want %q", got.Id(), "pool1")
+	}
+	want := map[string]interface{}{"project_id": 1, "region_id": 76, "cluster_name": "cluster1", "name": "pool1"}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("%s = %v, want %v", key, got.Get(key), value)
+		}
+	}
+}
+
+func TestK8sV2ClusterPoolManagedSeparately(t *testing.T) {
+	separatePools := schema.NewSet(schema.HashString, []interface{}{"pool1"})
+
+	// listed pools are skipped without looking them up
+	ok, err := resourceK8sV2ClusterPoolManagedSeparately(nil, "cluster1", separatePools, map[string]interface{}{"name": "pool1"})
+	if err != nil || !ok {
+		t.Errorf("resourceK8sV2ClusterPoolManagedSeparately() = %v, %v, want true, nil", ok, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -131,6 +132,13 @@ func resourceLBMember() *schema.Resource {
 					return diag.Errorf("Valid values: %d to %d got: %d", minWeight, maxWeight, v)
 				},
 			},
+			"drain_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Time in seconds to keep the member in the pool with weight 0 before deleting it, so established connections are drained instead of being reset. The member is deleted at once if it is 0. The drain counts against the delete timeout.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"subnet_id": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "ID of the subnet in which real server placed.",
@@ -328,6 +336,12 @@ func resourceLBMemberDelete(ctx context.Context, d *schema.ResourceData, m inter
 	mid := d.Id()
 	pid := d.Get("pool_id").(string)
 	timeout := int(d.Timeout(schema.TimeoutDelete).Seconds())
+	if drainTimeout := d.Get("drain_timeout").(int); drainTimeout > 0 {
+		if err := drainLBMember(ctx, client, pid, mid, time.Duration(drainTimeout)*time.Second, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	rc := GetConflictRetryConfig(timeout)
 	results, err := lbpools.DeleteMember(client, pid, mid, &gcorecloud.RequestOpts{
 		ConflictRetryAmount:   rc.Amount,
@@ -367,3 +381,42 @@ func resourceLBMemberDelete(ctx context.Context, d *schema.ResourceData, m inter
 	log.Printf("[DEBUG] Finish of LBMember deleting")
 	return diags
 }
+
+// lbMemberWeightOpts is the body of the pool member update request, weight is sent even if it is 0
+type lbMemberWeightOpts struct {
+	Weight int `json:"weight"`
+}
+
+// drainLBMember sets weight of the member to 0, so the load balancer stops sending new connections to it,
+// and waits drainTimeout for established connections to finish
+func drainLBMember(ctx context.Context, client *gcorecloud.ServiceClient, poolID, memberID string, drainTimeout time.Duration, timeout int) error {
+	log.Printf("[DEBUG] Drain LBMember %s for %s", memberID, drainTimeout)
+	rc := GetConflictRetryConfig(timeout)
+	var r tasks.Result
+	_, r.Err = client.Patch(client.ServiceURL(poolID, "member", memberID), lbMemberWeightOpts{Weight: 0}, &r.Body, &gcorecloud.RequestOpts{
+		OkCodes:               []int{200, 201},
+		ConflictRetryAmount:   rc.Amount,
+		ConflictRetryInterval: rc.Interval,
+	})
+	results, err := r.Extract()
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			return nil
+		default:
+			return fmt.Errorf("cannot set weight of LBMember %s to 0: %w", memberID, err)
+		}
+	}
+
+	taskID := results.Tasks[0]
+	if err := tasks.WaitForStatus(client, string(taskID), tasks.TaskStateFinished, timeout, true); err != nil {
+		return fmt.Errorf("cannot set weight of LBMember %s to 0: %w", memberID, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("cannot drain LBMember %s before timeout: %w", memberID, ctx.Err())
+	case <-time.After(drainTimeout):
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
@@ -194,10 +196,11 @@ func resourceLBPool() *schema.Resource {
 							Computed:    true,
 						},
 						"expected_codes": &schema.Schema{
-							Type:        schema.TypeString,
-							Description: "The list of HTTP status codes expected in response from the member to declare it healthy.",
-							Optional:    true,
-							Computed:    true,
+							Type:         schema.TypeString,
+							Description:  "The HTTP status codes expected in response from the member to declare it healthy. A comma separated list of codes and ranges, e.g. '200', '200,202', '200-399' or '200,300-302'.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateLBPoolExpectedCodes,
 						},
 					},
 				},
@@ -507,3 +510,20 @@ func resourceLBPoolDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	log.Printf("[DEBUG] Finish of LBPool deleting")
 	return diags
 }
+
+var lbPoolExpectedCodeRe = regexp.MustCompile(`^\d{3}(-\d{3})?$`)
+
+// validateLBPoolExpectedCodes checks that every comma separated item of
+// expected_codes is a status code like 200 or a range like 200-399.
+func validateLBPoolExpectedCodes(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	for _, item := range strings.Split(value, ",") {
+		if !lbPoolExpectedCodeRe.MatchString(strings.TrimSpace(item)) {
+			return nil, []error{fmt.Errorf("%s: %q must be a status code like 200 or a range like 200-399", k, item)}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,22 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import "testing"
+
+func TestValidateLBPoolExpectedCodes(t *testing.T) {
+	valid := []string{"200", "200,202", "200-399", "200,300-302", "200-204, 301"}
+	for _, v := range valid {
+		if _, errs := validateLBPoolExpectedCodes(v, "expected_codes"); len(errs) != 0 {
+			t.Errorf("validateLBPoolExpectedCodes(%q) = %v, want no errors", v, errs)
+		}
+	}
+
+	invalid := []string{"", "20", "200,", "200-", "200-3000", "2xx", "200;202"}
+	for _, v := range invalid {
+		if _, errs := validateLBPoolExpectedCodes(v, "expected_codes"); len(errs) == 0 {
+			t.Errorf("validateLBPoolExpectedCodes(%q) returned no errors", v)
+		}
+	}
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	typesSG "github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -149,6 +153,96 @@ func resourceLoadBalancerV2() *schema.Resource {
 				Description: "Datetime when load balancer was updated at the last time.",
 				Computed:    true,
 			},
+			"provisioning_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Provisioning status of the load balancer, eg. ACTIVE, PENDING_UPDATE or ERROR.",
+				Computed:    true,
+			},
+			"operating_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Operating status of the load balancer, eg. ONLINE, DEGRADED or ERROR.",
+				Computed:    true,
+			},
+			"security_group_rules": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Firewall rules of the security group attached to the load balancer VIP port. The security group is created when the first rule is set. Rules not created by Terraform, except the 'system' ones, are removed.",
+				Set:         secGroupUniqueID,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: fmt.Sprintf("Available value is '%s', '%s'", typesSG.RuleDirectionIngress, typesSG.RuleDirectionEgress),
+							ValidateDiagFunc: func(v interface{}, path cty.Path) diag.Diagnostics {
+								val := v.(string)
+								switch typesSG.RuleDirection(val) {
+								case typesSG.RuleDirectionIngress, typesSG.RuleDirectionEgress:
+									return nil
+								}
+								return diag.Errorf("wrong direction '%s', available value is '%s', '%s'", val, typesSG.RuleDirectionIngress, typesSG.RuleDirectionEgress)
+							},
+						},
+						"ethertype": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: fmt.Sprintf("Available value is '%s', '%s'", typesSG.EtherTypeIPv4, typesSG.EtherTypeIPv6),
+							ValidateDiagFunc: func(v interface{}, path cty.Path) diag.Diagnostics {
+								val := v.(string)
+								switch typesSG.EtherType(val) {
+								case typesSG.EtherTypeIPv4, typesSG.EtherTypeIPv6:
+									return nil
+								}
+								return diag.Errorf("wrong ethertype '%s', available value is '%s', '%s'", val, typesSG.EtherTypeIPv4, typesSG.EtherTypeIPv6)
+							},
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: fmt.Sprintf("Available value is %s", strings.Join(typesSG.Protocol("").StringList(), ",")),
+						},
+						"port_range_min": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          0,
+							ValidateDiagFunc: validatePortRange,
+						},
+						"port_range_max": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          0,
+							ValidateDiagFunc: validatePortRange,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+						"remote_ip_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"security_group_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group attached to the load balancer VIP port.",
+			},
 			"metadata_map": &schema.Schema{
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -245,6 +339,18 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(lbID.(string))
+	if diags := waitLoadBalancerV2Active(ctx, client, d.Id(), d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		// the load balancer stays in state as tainted and is replaced on the next apply instead of leaking
+		resourceLoadBalancerV2Read(ctx, d, m)
+		return diags
+	}
+
+	if rules := d.Get("security_group_rules").(*schema.Set); rules.Len() > 0 {
+		if err := updateLoadBalancerV2SecurityGroupRules(provider, client, d, schema.NewSet(secGroupUniqueID, nil), rules); err != nil {
+			resourceLoadBalancerV2Read(ctx, d, m)
+			return diag.FromErr(err)
+		}
+	}
 	resourceLoadBalancerV2Read(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish LoadBalancer creating (%s)", lbID)
@@ -274,6 +380,8 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, m i
 	d.Set("vrrp_ips", lb.VrrpIPs)
 	d.Set("vip_ip_family", lb.VipIPFamilyType)
 	d.Set("preferred_connectivity", lb.PreferredConnectivity)
+	d.Set("provisioning_status", lb.ProvisioningStatus.String())
+	d.Set("operating_status", lb.OperationStatus.String())
 
 	if lb.VipAddress != nil {
 		d.Set("vip_address", lb.VipAddress.String())
@@ -305,6 +413,27 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	sg, err := getLoadBalancerV2SecurityGroup(provider, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// rules are tracked only when managed by Terraform, so security groups set up otherwise don't produce a diff
+	manageRules := d.Get("security_group_rules").(*schema.Set).Len() > 0
+	if sg != nil {
+		d.Set("security_group_id", sg.ID)
+		if manageRules {
+			rules := schema.NewSet(secGroupUniqueID, convertSecurityGroupRules(filterSecurityGroupRules(sg.SecurityGroupRules)))
+			if err := d.Set("security_group_rules", rules); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		d.Set("security_group_id", "")
+		if manageRules {
+			d.Set("security_group_rules", schema.NewSet(secGroupUniqueID, nil))
+		}
+	}
+
 	log.Println("[DEBUG] Finish LoadBalancer reading")
 	return diags
 }
@@ -352,6 +481,13 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	if d.HasChange("security_group_rules") {
+		o, n := d.GetChange("security_group_rules")
+		if err := updateLoadBalancerV2SecurityGroupRules(provider, client, d, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("flavor") {
 		flavor := d.Get("flavor").(string)
 		timeout := int(d.Timeout(schema.TimeoutUpdate).Seconds())
@@ -380,6 +516,131 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	if change || d.HasChange("flavor") {
+		if diags := waitLoadBalancerV2Active(ctx, client, d.Id(), d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
 	log.Println("[DEBUG] Finish LoadBalancer updating")
 	return resourceLoadBalancerV2Read(ctx, d, m)
 }
+
+// waitLoadBalancerV2Active waits until the load balancer leaves pending provisioning states.
+// A finished task doesn't mean the load balancer works, its amphorae may still fail and leave it in ERROR.
+func waitLoadBalancerV2Active(ctx context.Context, client *gcorecloud.ServiceClient, lbID string, timeout time.Duration) diag.Diagnostics {
+	stateConf := retry.StateChangeConf{
+		Pending:    []string{"PENDING_CREATE", "PENDING_UPDATE"},
+		Target:     []string{LoadbalancerProvisioningStatusActive},
+		Refresh:    LoadbalancerProvisioningStatusRefreshedFunc(client, lbID),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	res, err := stateConf.WaitForStateContext(ctx)
+	if err == nil {
+		return nil
+	}
+
+	lb, ok := res.(*loadbalancers.LoadBalancer)
+	if !ok || lb == nil {
+		return diag.Errorf("wait for load balancer %s to become %s: %s", lbID, LoadbalancerProvisioningStatusActive, err)
+	}
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Load balancer %s is not active", lbID),
+		Detail: fmt.Sprintf("provisioning status %s, operating status %s: %s",
+			lb.ProvisioningStatus, lb.OperationStatus, err),
+	}}
+}
+
+// getLoadBalancerV2SecurityGroup returns the security group attached to the load balancer VIP port, nil if there is none
+func getLoadBalancerV2SecurityGroup(provider *gcorecloud.ProviderClient, client *gcorecloud.ServiceClient, d *schema.ResourceData) (*securitygroups.SecurityGroup, error) {
+	lbSGs, err := loadbalancers.ListCustomSecurityGroup(client, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get security group of load balancer %s: %w", d.Id(), err)
+	}
+	if len(lbSGs) == 0 {
+		return nil, nil
+	}
+
+	sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+	if err != nil {
+		return nil, err
+	}
+	sg, err := securitygroups.Get(sgClient, lbSGs[0].ID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get security group %s: %w", lbSGs[0].ID, err)
+	}
+	return sg, nil
+}
+
+// updateLoadBalancerV2SecurityGroupRules creates the security group of the VIP port if needed, deletes removed rules
+// and creates added ones. Unchanged rules keep their IDs.
+func updateLoadBalancerV2SecurityGroupRules(provider *gcorecloud.ProviderClient, client *gcorecloud.ServiceClient, d *schema.ResourceData, oldRules, newRules *schema.Set) error {
+	sg, err := getLoadBalancerV2SecurityGroup(provider, client, d)
+	if err != nil {
+		return err
+	}
+	if sg == nil {
+		if newRules.Len() == 0 {
+			return nil
+		}
+		log.Printf("[DEBUG] Creating security group of load balancer %s", d.Id())
+		if err := loadbalancers.CreateCustomSecurityGroup(client, d.Id()).ExtractErr(); err != nil {
+			return fmt.Errorf("cannot create security group of load balancer %s: %w", d.Id(), err)
+		}
+		if sg, err = getLoadBalancerV2SecurityGroup(provider, client, d); err != nil {
+			return err
+		}
+		if sg == nil {
+			return fmt.Errorf("security group of load balancer %s not found after creation", d.Id())
+		}
+	}
+
+	sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+
+	updateRequest := securitygroups.UpdateOpts{}
+	for _, rule := range oldRules.Difference(newRules).List() {
+		r := rule.(map[string]interface{})
+		if id, _ := r["id"].(string); id != "" {
+			updateRequest.ChangedRules = append(updateRequest.ChangedRules, securitygroups.UpdateSecurityGroupRuleOpts{
+				Action:              typesSG.ActionDelete,
+				SecurityGroupRuleID: id,
+			})
+		}
+	}
+	for _, rule := range newRules.Difference(oldRules).List() {
+		r := rule.(map[string]interface{})
+		changedRule := securitygroups.UpdateSecurityGroupRuleOpts{
+			Action:    typesSG.ActionCreate,
+			Direction: typesSG.RuleDirection(r["direction"].(string)),
+			EtherType: typesSG.EtherType(r["ethertype"].(string)),
+			Protocol:  typesSG.Protocol(r["protocol"].(string)),
+		}
+		if port := r["port_range_max"].(int); port != 0 {
+			changedRule.PortRangeMax = &port
+		}
+		if port := r["port_range_min"].(int); port != 0 {
+			changedRule.PortRangeMin = &port
+		}
+		if descr := r["description"].(string); descr != "" {
+			changedRule.Description = &descr
+		}
+		if remoteIPPrefix := r["remote_ip_prefix"].(string); remoteIPPrefix != "" {
+			changedRule.RemoteIPPrefix = &remoteIPPrefix
+		}
+		updateRequest.ChangedRules = append(updateRequest.ChangedRules, changedRule)
+	}
+	if len(updateRequest.ChangedRules) == 0 {
+		return nil
+	}
+
+	if _, err := securitygroups.Update(sgClient, sg.ID, updateRequest).Extract(); err != nil {
+		return fmt.Errorf("cannot update rules of security group %s: %w", sg.ID, err)
+	}
+	return nil
+}
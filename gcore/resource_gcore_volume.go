@@ -7,6 +7,10 @@ import (
 	"log"
 	"time"
 
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	instancesV2 "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/instances"
+	typesV2 "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/snapshot/v1/snapshots"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata"
 	metadatav1 "github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
@@ -21,6 +25,7 @@ import (
 const volumeDeleting int = 1200
 const volumeCreatingTimeout int = 1200
 const volumeExtending int = 1200
+const volumeRestoring int = 1200
 const volumesPoint = "volumes"
 
 func resourceVolume() *schema.Resource {
@@ -29,6 +34,7 @@ func resourceVolume() *schema.Resource {
 		ReadContext:   resourceVolumeRead,
 		UpdateContext: resourceVolumeUpdate,
 		DeleteContext: resourceVolumeDelete,
+		CustomizeDiff: resourceVolumeCustomizeDiff,
 		Description:   "Represent volume. A volume is a file storage which is similar to SSD and HDD hard disks but located in the cloud",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -120,6 +126,12 @@ func resourceVolume() *schema.Resource {
 				ForceNew:    true,
 				Description: "Mandatory if volume is created from a snapshot",
 			},
+			"restore_from_snapshot_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `ID of the volume snapshot to restore the volume content from in place, it must be the latest snapshot of the volume.
+				Instances the volume is attached to are stopped during restoring and started again afterwards. Can't be set on creation`,
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -326,6 +338,18 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
+	if d.HasChange("restore_from_snapshot_id") {
+		oldSnapshotID, newSnapshotID := d.GetChange("restore_from_snapshot_id")
+		snapshotID := newSnapshotID.(string)
+		if snapshotID != "" {
+			if err := RestoreVolumeFromSnapshot(provider, d, client, volume, snapshotID); err != nil {
+				// keep the change planned until the volume is restored
+				d.Set("restore_from_snapshot_id", oldSnapshotID)
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	if d.HasChange("metadata_map") {
 		_, nmd := d.GetChange("metadata_map")
 
@@ -455,3 +479,99 @@ func ExtendVolume(client *gcorecloud.ServiceClient, volumeID string, newSize int
 	log.Printf("[DEBUG] Finish waiting.")
 	return nil
 }
+
+// RestoreVolumeFromSnapshot reverts the volume to the snapshot. Attached instances are stopped for the time of restoring.
+// The API reverts the volume to its latest snapshot only, so any other snapshot is rejected.
+func RestoreVolumeFromSnapshot(provider *gcorecloud.ProviderClient, d *schema.ResourceData, client *gcorecloud.ServiceClient, volume *volumes.Volume, snapshotID string) (err error) {
+	snapshotClient, err := CreateClient(provider, d, snapshotsPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	volumeSnapshots, err := snapshots.ListAll(snapshotClient, snapshots.ListOpts{VolumeID: volume.ID})
+	if err != nil {
+		return fmt.Errorf("cannot get snapshots of volume with ID: %s. Error: %w", volume.ID, err)
+	}
+	latest := latestVolumeSnapshot(volumeSnapshots)
+	if latest == nil || latest.ID != snapshotID {
+		return fmt.Errorf("volume with ID: %s can be restored from its latest snapshot only, %s is not", volume.ID, snapshotID)
+	}
+
+	instanceClientV1, err := CreateClient(provider, d, InstancePoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	instanceClient, err := CreateClient(provider, d, InstancePoint, versionPointV2)
+	if err != nil {
+		return err
+	}
+
+	var stopped []string
+	// instances are started again whether restoring succeeded or not
+	defer func() {
+		for _, instanceID := range stopped {
+			log.Printf("[DEBUG] Start instance %s after restoring volume %s", instanceID, volume.ID)
+			results, startErr := instancesV2.Action(instanceClient, instanceID, instancesV2.ActionOpts{Action: typesV2.InstanceActionTypeStart}).Extract()
+			if startErr == nil {
+				startErr = waitInstanceOperation(client, results.Tasks[0])
+			}
+			if startErr != nil {
+				err = errors.Join(err, fmt.Errorf("cannot start instance with ID: %s. Error: %w", instanceID, startErr))
+			}
+		}
+	}()
+
+	for _, attachment := range volume.Attachments {
+		instanceID := attachment.ServerID
+		instance, err := instances.Get(instanceClientV1, instanceID).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot get instance with ID: %s. Error: %w", instanceID, err)
+		}
+		if instance.VMState == InstanceVMStateStopped {
+			continue
+		}
+
+		log.Printf("[DEBUG] Stop instance %s to restore volume %s", instanceID, volume.ID)
+		results, err := instancesV2.Action(instanceClient, instanceID, instancesV2.ActionOpts{Action: typesV2.InstanceActionTypeStop}).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot stop instance with ID: %s. Error: %w", instanceID, err)
+		}
+		stopped = append(stopped, instanceID)
+		if err := waitInstanceOperation(client, results.Tasks[0]); err != nil {
+			return err
+		}
+	}
+
+	results, err := volumes.Revert(client, volume.ID).Extract()
+	if err != nil {
+		return fmt.Errorf("cannot restore volume with ID: %s from snapshot %s. Error: %w", volume.ID, snapshotID, err)
+	}
+
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, volumeRestoring, func(task tasks.TaskID) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Finish restoring volume.")
+	return nil
+}
+
+func latestVolumeSnapshot(volumeSnapshots []snapshots.Snapshot) *snapshots.Snapshot {
+	var latest *snapshots.Snapshot
+	for i := range volumeSnapshots {
+		if latest == nil || volumeSnapshots[i].CreatedAt.After(latest.CreatedAt.Time) {
+			latest = &volumeSnapshots[i]
+		}
+	}
+	return latest
+}
+
+func resourceVolumeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" && diff.Get("restore_from_snapshot_id").(string) != "" {
+		return fmt.Errorf("restore_from_snapshot_id can't be set on volume creation, use snapshot_id to create the volume from a snapshot")
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+//go:build cloud
+// +build cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// GCORE_SWEEP_PREFIX_VAR selects resources deleted by sweepers: cloud resources with a metadata key
+// starting with the prefix and CDN resources with a description starting with it.
+const GCORE_SWEEP_PREFIX_VAR VarName = "GCORE_SWEEP_PREFIX"
+
+const sweepDeleteTimeout = 1200
+
+// TestMain runs sweepers instead of tests when -sweep flag is passed, eg.
+// GCORE_SWEEP_PREFIX=tf-test go test ./gcore -tags cloud -v -sweep=all
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("gcore_instance", &resource.Sweeper{
+		Name: "gcore_instance",
+		F:    sweepInstances,
+	})
+	resource.AddTestSweepers("gcore_volume", &resource.Sweeper{
+		Name:         "gcore_volume",
+		F:            sweepVolumes,
+		Dependencies: []string{"gcore_instance"},
+	})
+	resource.AddTestSweepers("gcore_loadbalancerv2", &resource.Sweeper{
+		Name:         "gcore_loadbalancerv2",
+		F:            sweepLoadBalancers,
+		Dependencies: []string{"gcore_instance"},
+	})
+	resource.AddTestSweepers("gcore_cdn_resource", &resource.Sweeper{
+		Name: "gcore_cdn_resource",
+		F:    sweepCDNResources,
+	})
+}
+
+// sweepPrefix refuses to sweep without a prefix, so that a shared account is never wiped out
+func sweepPrefix() (string, error) {
+	prefix := getEnv(GCORE_SWEEP_PREFIX_VAR)
+	if prefix == "" {
+		return "", fmt.Errorf("'%s' must be set to sweep resources", GCORE_SWEEP_PREFIX_VAR)
+	}
+	return prefix, nil
+}
+
+func sweepMetadataMatch(keys []string, prefix string) bool {
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sweepMetadataKeys(md []metadata.Metadata) []string {
+	keys := make([]string, 0, len(md))
+	for _, item := range md {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+func sweepWaitTask(client *gcorecloud.ServiceClient, results *tasks.TaskResults) error {
+	taskID := results.Tasks[0]
+	_, err := tasks.WaitTaskAndReturnResult(client, taskID, true, sweepDeleteTimeout, func(task tasks.TaskID) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func sweepInstances(region string) error {
+	prefix, err := sweepPrefix()
+	if err != nil {
+		return err
+	}
+	cfg, err := createTestConfig()
+	if err != nil {
+		return err
+	}
+	client, err := CreateTestClient(cfg.Provider, InstancePoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+
+	instanceList, err := instances.ListAll(client, instances.ListOpts{IncludeBaremetal: true})
+	if err != nil {
+		return fmt.Errorf("list instances: %w", err)
+	}
+	for _, instance := range instanceList {
+		keys := make([]string, 0, len(instance.Metadata))
+		for key := range instance.Metadata {
+			keys = append(keys, key)
+		}
+		if !sweepMetadataMatch(keys, prefix) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping instance %s (%s)", instance.Name, instance.ID)
+		results, err := instances.Delete(client, instance.ID, instances.DeleteOpts{DeleteFloatings: true}).Extract()
+		if err != nil {
+			return fmt.Errorf("delete instance %s: %w", instance.ID, err)
+		}
+		if err := sweepWaitTask(client, results); err != nil {
+			return fmt.Errorf("delete instance %s: %w", instance.ID, err)
+		}
+	}
+	return nil
+}
+
+func sweepVolumes(region string) error {
+	prefix, err := sweepPrefix()
+	if err != nil {
+		return err
+	}
+	cfg, err := createTestConfig()
+	if err != nil {
+		return err
+	}
+	client, err := CreateTestClient(cfg.Provider, volumesPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+
+	volumeList, err := volumes.ListAll(client, volumes.ListOpts{})
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+	for _, volume := range volumeList {
+		if !sweepMetadataMatch(sweepMetadataKeys(volume.Metadata), prefix) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping volume %s (%s)", volume.Name, volume.ID)
+		results, err := volumes.Delete(client, volume.ID, volumes.DeleteOpts{}).Extract()
+		if err != nil {
+			return fmt.Errorf("delete volume %s: %w", volume.ID, err)
+		}
+		if err := sweepWaitTask(client, results); err != nil {
+			return fmt.Errorf("delete volume %s: %w", volume.ID, err)
+		}
+	}
+	return nil
+}
+
+func sweepLoadBalancers(region string) error {
+	prefix, err := sweepPrefix()
+	if err != nil {
+		return err
+	}
+	cfg, err := createTestConfig()
+	if err != nil {
+		return err
+	}
+	client, err := CreateTestClient(cfg.Provider, LoadBalancersPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+
+	lbList, err := loadbalancers.ListAll(client, loadbalancers.ListOpts{})
+	if err != nil {
+		return fmt.Errorf("list load balancers: %w", err)
+	}
+	for _, lb := range lbList {
+		if !sweepMetadataMatch(sweepMetadataKeys(lb.Metadata), prefix) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping load balancer %s (%s)", lb.Name, lb.ID)
+		results, err := loadbalancers.Delete(client, lb.ID, nil).Extract()
+		if err != nil {
+			return fmt.Errorf("delete load balancer %s: %w", lb.ID, err)
+		}
+		if err := sweepWaitTask(client, results); err != nil {
+			return fmt.Errorf("delete load balancer %s: %w", lb.ID, err)
+		}
+	}
+	return nil
+}
+
+// sweepCDNResources deletes CDN resources by description, as they have no metadata
+func sweepCDNResources(region string) error {
+	prefix, err := sweepPrefix()
+	if err != nil {
+		return err
+	}
+	cfg, err := createTestConfig()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	const limit = 100
+	var matched []int64
+	for offset := 0; ; offset += limit {
+		page, err := cfg.CDNClient.Resources().List(ctx, limit, offset)
+		if err != nil {
+			return fmt.Errorf("list CDN resources: %w", err)
+		}
+		for _, cdnResource := range page {
+			if strings.HasPrefix(cdnResource.Description, prefix) {
+				log.Printf("[INFO] Sweeping CDN resource %s (%d)", cdnResource.Cname, cdnResource.ID)
+				matched = append(matched, cdnResource.ID)
+			}
+		}
+		if len(page) < limit {
+			break
+		}
+	}
+	// resources are deleted after listing, so that deletes don't shift the pages
+	for _, id := range matched {
+		if err := cfg.CDNClient.Resources().Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete CDN resource %d: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -56,6 +56,9 @@ type Config struct {
 	CDNMutex      *sync.Mutex
 	StorageClient *storageSDK.SDK
 	DNSClient     *dnssdk.Client
+	PlatformAPI   string
+
+	StrictDeprecations bool
 }
 
 type Project struct {
@@ -258,7 +261,7 @@ func extractInstanceInterfacesMapV2(interfaces []interface{}) ([]instances.Inter
 
 		name := inter["name"].(string)
 		I.Name = &name
-		I.IPFamily = types.IPFamilyType(inter["ip_family"].(string))
+		I.IPFamily = instanceInterfaceIPFamily(inter)
 
 		Interfaces[i] = instances.InterfaceInstanceCreateOpts{
 			InterfaceOpts:  I,
@@ -268,6 +271,15 @@ func extractInstanceInterfacesMapV2(interfaces []interface{}) ([]instances.Inter
 	return Interfaces, nil
 }
 
+// instanceInterfaceIPFamily returns ip family requested for the interface, ipv6_enabled turns it into dual stack
+func instanceInterfaceIPFamily(iface map[string]interface{}) types.IPFamilyType {
+	family, _ := iface["ip_family"].(string)
+	if enabled, _ := iface["ipv6_enabled"].(bool); enabled && types.IPFamilyType(family) != types.IPv6IPFamilyType {
+		return types.DualStackIPFamilyType
+	}
+	return types.IPFamilyType(family)
+}
+
 func extractInstanceInterfacesMap(interfaces []interface{}) ([]instances.InterfaceInstanceCreateOpts, error) {
 	Interfaces := make([]instances.InterfaceInstanceCreateOpts, len(interfaces))
 	for i, iface := range interfaces {